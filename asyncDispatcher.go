@@ -0,0 +1,267 @@
+package logger
+
+import (
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/Dharitri-org/me-core/core/check"
+)
+
+const defaultDropReportInterval = time.Second * 10
+const asyncDispatcherLoggerName = "logger/async"
+
+// OverflowPolicy defines what happens with a log line when the asynchronous queue is full
+type OverflowPolicy byte
+
+const (
+	// OverflowBlock makes the caller wait until there is room in the queue
+	OverflowBlock OverflowPolicy = iota
+	// OverflowDropNewest drops the log line that could not be queued
+	OverflowDropNewest
+	// OverflowDropOldest drops the oldest queued log line in order to make room for the new one
+	OverflowDropOldest
+	// OverflowDropBelowLevel drops the log line that could not be queued if its level is below the configured
+	// level, otherwise makes the caller wait until there is room in the queue
+	OverflowDropBelowLevel
+)
+
+// ArgsAsyncOutput is the argument used to enable the asynchronous output mode
+type ArgsAsyncOutput struct {
+	QueueSize          int
+	OverflowPolicy     OverflowPolicy
+	DropBelowLevel     LogLevel
+	DropReportInterval time.Duration
+}
+
+func checkArgsAsyncOutput(args ArgsAsyncOutput) error {
+	if args.QueueSize < 1 {
+		return ErrInvalidQueueSize
+	}
+	if args.OverflowPolicy > OverflowDropBelowLevel {
+		return ErrInvalidOverflowPolicy
+	}
+
+	return nil
+}
+
+// asyncDispatcher holds a bounded queue of converted log lines that are written on the observers by a background
+// go routine. Each time the drop report interval elapses, if log lines were dropped since the last report, a
+// log line containing the number of dropped log lines is written on the observers
+type asyncDispatcher struct {
+	mutState       sync.RWMutex
+	isClosed       bool
+	blockedSenders sync.WaitGroup
+	queue          chan LogLineHandler
+	overflowPolicy OverflowPolicy
+	dropBelowLevel LogLevel
+	write          func(line LogLineHandler)
+	numDropped     uint64
+	numReported    uint64
+	chFlush        chan chan struct{}
+	chStop         chan struct{}
+	chDone         chan struct{}
+}
+
+// newAsyncDispatcher creates the dispatcher and starts its background go routine. If a previous dispatcher, writing
+// on the same observers, is provided, the go routine closes it before processing the queue, so the log lines queued
+// on the previous dispatcher are written first
+func newAsyncDispatcher(args ArgsAsyncOutput, write func(line LogLineHandler), previous *asyncDispatcher) *asyncDispatcher {
+	dropReportInterval := args.DropReportInterval
+	if dropReportInterval <= 0 {
+		dropReportInterval = defaultDropReportInterval
+	}
+
+	ad := &asyncDispatcher{
+		queue:          make(chan LogLineHandler, args.QueueSize),
+		overflowPolicy: args.OverflowPolicy,
+		dropBelowLevel: args.DropBelowLevel,
+		write:          write,
+		chFlush:        make(chan chan struct{}),
+		chStop:         make(chan struct{}),
+		chDone:         make(chan struct{}),
+	}
+
+	go ad.processQueue(dropReportInterval, previous)
+
+	return ad
+}
+
+// enqueue adds the line in the queue applying the overflow policy. Returns false if the dispatcher was closed.
+// The callers waiting for room in the queue do not hold the state lock, so closing the dispatcher is not blocked by
+// them and releases them
+func (ad *asyncDispatcher) enqueue(line LogLineHandler) bool {
+	ad.mutState.RLock()
+	if ad.isClosed {
+		ad.mutState.RUnlock()
+		return false
+	}
+
+	select {
+	case ad.queue <- line:
+		ad.mutState.RUnlock()
+		return true
+	default:
+	}
+
+	switch ad.overflowPolicy {
+	case OverflowDropNewest:
+		ad.markDropped()
+	case OverflowDropOldest:
+		ad.enqueueDroppingOldest(line)
+	case OverflowDropBelowLevel:
+		if getLineLogLevel(line) < ad.dropBelowLevel {
+			ad.markDropped()
+			break
+		}
+		return ad.enqueueBlocking(line)
+	default:
+		return ad.enqueueBlocking(line)
+	}
+	ad.mutState.RUnlock()
+
+	return true
+}
+
+// enqueueBlocking waits for room in the queue after releasing the state lock held by the caller
+func (ad *asyncDispatcher) enqueueBlocking(line LogLineHandler) bool {
+	ad.blockedSenders.Add(1)
+	ad.mutState.RUnlock()
+	defer ad.blockedSenders.Done()
+
+	select {
+	case ad.queue <- line:
+		return true
+	case <-ad.chStop:
+		return false
+	}
+}
+
+func (ad *asyncDispatcher) enqueueDroppingOldest(line LogLineHandler) {
+	for {
+		select {
+		case ad.queue <- line:
+			return
+		default:
+		}
+
+		select {
+		case <-ad.queue:
+			ad.markDropped()
+		default:
+		}
+	}
+}
+
+func getLineLogLevel(line LogLineHandler) LogLevel {
+	if check.IfNil(line) {
+		return LogTrace
+	}
+
+	return LogLevel(line.GetLogLevel())
+}
+
+func (ad *asyncDispatcher) markDropped() {
+	atomic.AddUint64(&ad.numDropped, 1)
+}
+
+func (ad *asyncDispatcher) droppedLines() uint64 {
+	return atomic.LoadUint64(&ad.numDropped)
+}
+
+func (ad *asyncDispatcher) queueLength() int {
+	return len(ad.queue)
+}
+
+func (ad *asyncDispatcher) processQueue(dropReportInterval time.Duration, previous *asyncDispatcher) {
+	if previous != nil {
+		previous.close()
+	}
+
+	ticker := time.NewTicker(dropReportInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case line := <-ad.queue:
+			ad.write(line)
+		case chFlushed := <-ad.chFlush:
+			ad.drainQueue()
+			close(chFlushed)
+		case <-ticker.C:
+			ad.reportDropped()
+		case <-ad.chStop:
+			ad.drainQueue()
+			ad.blockedSenders.Wait()
+			ad.drainQueue()
+			ad.reportDropped()
+			close(ad.chDone)
+			return
+		}
+	}
+}
+
+func (ad *asyncDispatcher) drainQueue() {
+	for {
+		select {
+		case line := <-ad.queue:
+			ad.write(line)
+		default:
+			return
+		}
+	}
+}
+
+func (ad *asyncDispatcher) reportDropped() {
+	numDropped := ad.droppedLines()
+	numNotReported := numDropped - ad.numReported
+	if numNotReported == 0 {
+		return
+	}
+	ad.numReported = numDropped
+
+	line := &LogLineWrapper{}
+	line.LoggerName = asyncDispatcherLoggerName
+	line.Correlation = GetCorrelation()
+	line.Message = "log lines dropped"
+	line.LogLevel = int32(LogWarning)
+	line.Args = []string{"num dropped", strconv.FormatUint(numNotReported, 10), "total dropped", strconv.FormatUint(numDropped, 10)}
+	line.Timestamp = time.Now().UnixNano()
+
+	ad.write(line)
+}
+
+// flush waits until all the log lines queued before the call are written or until the dispatcher is closed
+func (ad *asyncDispatcher) flush() {
+	ad.mutState.RLock()
+	isClosed := ad.isClosed
+	ad.mutState.RUnlock()
+	if isClosed {
+		return
+	}
+
+	chFlushed := make(chan struct{})
+	select {
+	case ad.chFlush <- chFlushed:
+	case <-ad.chStop:
+		<-ad.chDone
+		return
+	}
+
+	<-chFlushed
+}
+
+// close stops accepting new log lines and waits until all the queued log lines are written
+func (ad *asyncDispatcher) close() {
+	ad.mutState.Lock()
+	if ad.isClosed {
+		ad.mutState.Unlock()
+		return
+	}
+	ad.isClosed = true
+	ad.mutState.Unlock()
+
+	close(ad.chStop)
+	<-ad.chDone
+}
@@ -0,0 +1,316 @@
+package logger_test
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	logger "github.com/Dharitri-org/me-core-logger-go"
+	"github.com/Dharitri-org/me-core-logger-go/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type blockingObserver struct {
+	mut          sync.Mutex
+	messages     []string
+	args         [][]string
+	chWriteStart chan struct{}
+	chUnblock    chan struct{}
+}
+
+func newBlockingObserver(los logger.LogOutputHandler) *blockingObserver {
+	bo := &blockingObserver{
+		chWriteStart: make(chan struct{}, 100),
+		chUnblock:    make(chan struct{}),
+	}
+	_ = los.AddObserver(
+		&mock.WriterStub{
+			WriteCalled: func(p []byte) (n int, err error) {
+				bo.chWriteStart <- struct{}{}
+				<-bo.chUnblock
+				return len(p), nil
+			},
+		},
+		&mock.FormatterStub{
+			OutputCalled: func(line logger.LogLineHandler) []byte {
+				bo.mut.Lock()
+				bo.messages = append(bo.messages, line.GetMessage())
+				bo.args = append(bo.args, line.GetArgs())
+				bo.mut.Unlock()
+				return nil
+			},
+		},
+	)
+
+	return bo
+}
+
+func (bo *blockingObserver) getMessages() []string {
+	bo.mut.Lock()
+	defer bo.mut.Unlock()
+
+	return append([]string{}, bo.messages...)
+}
+
+func outputMessages(los logger.LogOutputHandler, level logger.LogLevel, messages ...string) {
+	for _, message := range messages {
+		los.Output(&logger.LogLine{Message: message, LogLevel: level})
+	}
+}
+
+func TestLogOutputSubject_EnableAsyncInvalidArgsShouldErr(t *testing.T) {
+	t.Parallel()
+
+	los := logger.NewLogOutputSubject()
+
+	err := los.EnableAsync(logger.ArgsAsyncOutput{QueueSize: 0})
+	assert.Equal(t, logger.ErrInvalidQueueSize, err)
+
+	err = los.EnableAsync(logger.ArgsAsyncOutput{QueueSize: 1, OverflowPolicy: 42})
+	assert.Equal(t, logger.ErrInvalidOverflowPolicy, err)
+
+	err = los.EnableAsync(logger.ArgsAsyncOutput{QueueSize: 1})
+	assert.Nil(t, err)
+
+	err = los.EnableAsync(logger.ArgsAsyncOutput{QueueSize: 1})
+	assert.Equal(t, logger.ErrAsyncOutputAlreadyEnabled, err)
+
+	los.DisableAsync()
+}
+
+func TestLogOutputSubject_AsyncShouldWriteAllLinesOnDisable(t *testing.T) {
+	t.Parallel()
+
+	los := logger.NewLogOutputSubject()
+	bo := newBlockingObserver(los)
+	close(bo.chUnblock)
+
+	err := los.EnableAsync(logger.ArgsAsyncOutput{QueueSize: 10})
+	require.Nil(t, err)
+
+	outputMessages(los, logger.LogInfo, "a", "b", "c")
+	los.DisableAsync()
+	outputMessages(los, logger.LogInfo, "d")
+
+	assert.Equal(t, []string{"a", "b", "c", "d"}, bo.getMessages())
+	assert.Equal(t, uint64(0), los.DroppedLines())
+}
+
+func TestLogOutputSubject_AsyncOverflowPolicies(t *testing.T) {
+	t.Parallel()
+
+	t.Run("drop newest", func(t *testing.T) {
+		t.Parallel()
+
+		los := logger.NewLogOutputSubject()
+		bo := newBlockingObserver(los)
+		_ = los.EnableAsync(logger.ArgsAsyncOutput{QueueSize: 1, OverflowPolicy: logger.OverflowDropNewest})
+
+		outputMessages(los, logger.LogInfo, "a")
+		<-bo.chWriteStart
+		outputMessages(los, logger.LogInfo, "b", "c", "d")
+		assert.Equal(t, uint64(2), los.DroppedLines())
+
+		close(bo.chUnblock)
+		los.DisableAsync()
+
+		assert.Equal(t, []string{"a", "b"}, bo.getMessages()[:2])
+	})
+	t.Run("drop oldest", func(t *testing.T) {
+		t.Parallel()
+
+		los := logger.NewLogOutputSubject()
+		bo := newBlockingObserver(los)
+		_ = los.EnableAsync(logger.ArgsAsyncOutput{QueueSize: 1, OverflowPolicy: logger.OverflowDropOldest})
+
+		outputMessages(los, logger.LogInfo, "a")
+		<-bo.chWriteStart
+		outputMessages(los, logger.LogInfo, "b", "c", "d")
+		assert.Equal(t, uint64(2), los.DroppedLines())
+
+		close(bo.chUnblock)
+		los.DisableAsync()
+
+		assert.Equal(t, []string{"a", "d"}, bo.getMessages()[:2])
+	})
+	t.Run("drop below level", func(t *testing.T) {
+		t.Parallel()
+
+		los := logger.NewLogOutputSubject()
+		bo := newBlockingObserver(los)
+		_ = los.EnableAsync(logger.ArgsAsyncOutput{
+			QueueSize:      1,
+			OverflowPolicy: logger.OverflowDropBelowLevel,
+			DropBelowLevel: logger.LogInfo,
+		})
+
+		outputMessages(los, logger.LogInfo, "a")
+		<-bo.chWriteStart
+		outputMessages(los, logger.LogInfo, "b")
+		outputMessages(los, logger.LogDebug, "c", "d")
+		assert.Equal(t, uint64(2), los.DroppedLines())
+
+		chDone := make(chan struct{})
+		go func() {
+			outputMessages(los, logger.LogWarning, "e")
+			close(chDone)
+		}()
+
+		select {
+		case <-chDone:
+			assert.Fail(t, "should have blocked")
+		case <-time.After(time.Millisecond * 50):
+		}
+
+		close(bo.chUnblock)
+		<-chDone
+		los.DisableAsync()
+
+		assert.Equal(t, []string{"a", "b", "e"}, bo.getMessages()[:3])
+	})
+	t.Run("block", func(t *testing.T) {
+		t.Parallel()
+
+		los := logger.NewLogOutputSubject()
+		bo := newBlockingObserver(los)
+		_ = los.EnableAsync(logger.ArgsAsyncOutput{QueueSize: 1, OverflowPolicy: logger.OverflowBlock})
+
+		outputMessages(los, logger.LogInfo, "a")
+		<-bo.chWriteStart
+		outputMessages(los, logger.LogInfo, "b")
+
+		chDone := make(chan struct{})
+		go func() {
+			outputMessages(los, logger.LogTrace, "c")
+			close(chDone)
+		}()
+
+		select {
+		case <-chDone:
+			assert.Fail(t, "should have blocked")
+		case <-time.After(time.Millisecond * 50):
+		}
+
+		close(bo.chUnblock)
+		<-chDone
+		los.DisableAsync()
+
+		assert.Equal(t, []string{"a", "b", "c"}, bo.getMessages())
+		assert.Equal(t, uint64(0), los.DroppedLines())
+	})
+}
+
+func TestLogOutputSubject_AsyncShouldReportDroppedLines(t *testing.T) {
+	t.Parallel()
+
+	los := logger.NewLogOutputSubject()
+	bo := newBlockingObserver(los)
+	_ = los.EnableAsync(logger.ArgsAsyncOutput{
+		QueueSize:          1,
+		OverflowPolicy:     logger.OverflowDropNewest,
+		DropReportInterval: time.Millisecond * 10,
+	})
+
+	outputMessages(los, logger.LogInfo, "a")
+	<-bo.chWriteStart
+	outputMessages(los, logger.LogInfo, "b", "c", "d")
+	close(bo.chUnblock)
+	assert.Eventually(t, func() bool {
+		return len(bo.getMessages()) == 3
+	}, time.Second, time.Millisecond)
+	los.DisableAsync()
+
+	messages := bo.getMessages()
+	require.Equal(t, []string{"a", "b", "log lines dropped"}, messages)
+	bo.mut.Lock()
+	assert.Equal(t, []string{"num dropped", "2", "total dropped", "2"}, bo.args[2])
+	bo.mut.Unlock()
+}
+
+func TestLogOutputSubject_FlushShouldWriteTheQueuedLines(t *testing.T) {
+	t.Parallel()
+
+	los := logger.NewLogOutputSubject()
+	bo := newBlockingObserver(los)
+	err := los.EnableAsync(logger.ArgsAsyncOutput{QueueSize: 10})
+	require.Nil(t, err)
+	defer los.DisableAsync()
+
+	outputMessages(los, logger.LogInfo, "a", "b", "c")
+	<-bo.chWriteStart
+
+	chFlushed := make(chan error)
+	go func() {
+		chFlushed <- los.Flush()
+	}()
+	select {
+	case <-chFlushed:
+		assert.Fail(t, "flush should wait for the queued lines")
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	close(bo.chUnblock)
+	select {
+	case err = <-chFlushed:
+		assert.Nil(t, err)
+	case <-time.After(time.Second):
+		assert.Fail(t, "flush should have returned")
+	}
+	assert.Equal(t, []string{"a", "b", "c"}, bo.getMessages())
+}
+
+func TestLogOutputSubject_DisableAsyncShouldReleaseTheBlockedWriterLoggingBack(t *testing.T) {
+	t.Parallel()
+
+	for _, policy := range []logger.OverflowPolicy{logger.OverflowBlock, logger.OverflowDropBelowLevel} {
+		los := logger.NewLogOutputSubject()
+		mutMessages := sync.Mutex{}
+		messages := make([]string, 0)
+		chFirstWrite := make(chan struct{})
+		onceFirstWrite := sync.Once{}
+		_ = los.AddObserver(
+			&mock.WriterStub{
+				WriteCalled: func(p []byte) (n int, err error) {
+					mutMessages.Lock()
+					messages = append(messages, string(p))
+					mutMessages.Unlock()
+					onceFirstWrite.Do(func() {
+						close(chFirstWrite)
+					})
+					if p[0] == 'o' {
+						los.Output(&logger.LogLine{Message: "inner", LogLevel: logger.LogError})
+					}
+					return len(p), nil
+				},
+			},
+			createMessageFormatterStub(),
+		)
+		err := los.EnableAsync(logger.ArgsAsyncOutput{QueueSize: 1, OverflowPolicy: policy, DropBelowLevel: logger.LogWarning})
+		require.Nil(t, err)
+
+		chOutputDone := make(chan struct{})
+		go func() {
+			outputMessages(los, logger.LogError, "outer", "outer", "outer")
+			close(chOutputDone)
+		}()
+		// the background go routine is writing and logs back while the queue is filled by the outer lines
+		<-chFirstWrite
+
+		chDisabled := make(chan struct{})
+		go func() {
+			los.DisableAsync()
+			close(chDisabled)
+		}()
+		select {
+		case <-chDisabled:
+		case <-time.After(time.Second):
+			require.Fail(t, "disabling the asynchronous mode should not deadlock")
+		}
+		<-chOutputDone
+
+		mutMessages.Lock()
+		assert.ElementsMatch(t, []string{"outer", "outer", "outer", "inner", "inner", "inner"}, messages)
+		mutMessages.Unlock()
+	}
+}
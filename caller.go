@@ -0,0 +1,146 @@
+package logger
+
+import (
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/Dharitri-org/me-core-logger-go/proto"
+)
+
+// callerSkip is the number of stack frames between captureCaller and the code calling a public logger method:
+// captureCaller, outputMessageFromLogLevel (or outputFields) and the public logger method
+const callerSkip = 3
+
+// CallerPathDisplay defines how the file path of the caller is displayed by the formatters
+type CallerPathDisplay byte
+
+const (
+	// CallerShortPath displays only the directory and the name of the file (e.g. "process/block.go") and the
+	// function name without its package path
+	CallerShortPath CallerPathDisplay = iota
+	// CallerLongPath displays the full file path and the full function name
+	CallerLongPath
+)
+
+var globalCaller callerSettings
+
+// callerSettings holds the caller capture mode settings
+type callerSettings struct {
+	mut         sync.RWMutex
+	enabled     bool
+	pathDisplay CallerPathDisplay
+}
+
+// ToggleCaller enables or disables the capture of the caller location (file, line and function) for log lines
+func ToggleCaller(enable bool) {
+	globalCaller.mut.Lock()
+	globalCaller.enabled = enable
+	globalCaller.mut.Unlock()
+}
+
+// IsEnabledCaller returns whether the caller location is captured
+func IsEnabledCaller() bool {
+	globalCaller.mut.RLock()
+	enabled := globalCaller.enabled
+	globalCaller.mut.RUnlock()
+
+	return enabled
+}
+
+// SetCallerPathDisplay sets how the file path and the function of the caller are displayed
+func SetCallerPathDisplay(pathDisplay CallerPathDisplay) {
+	globalCaller.mut.Lock()
+	globalCaller.pathDisplay = pathDisplay
+	globalCaller.mut.Unlock()
+}
+
+// GetCallerPathDisplay returns how the file path and the function of the caller are displayed
+func GetCallerPathDisplay() CallerPathDisplay {
+	globalCaller.mut.RLock()
+	pathDisplay := globalCaller.pathDisplay
+	globalCaller.mut.RUnlock()
+
+	return pathDisplay
+}
+
+// captureCaller returns the caller location found at the provided skip depth if the caller capture mode is enabled
+func captureCaller(skip int) proto.LogCallerMessage {
+	if !IsEnabledCaller() {
+		return proto.LogCallerMessage{}
+	}
+
+	var pcs [1]uintptr
+	if runtime.Callers(skip+1, pcs[:]) == 0 {
+		return proto.LogCallerMessage{}
+	}
+
+	return callerFromPC(pcs[0])
+}
+
+// callerFromPC returns the caller location of the provided program counter, as returned by runtime.Callers
+func callerFromPC(pc uintptr) proto.LogCallerMessage {
+	frame, _ := runtime.CallersFrames([]uintptr{pc}).Next()
+
+	return proto.LogCallerMessage{
+		File:     frame.File,
+		Line:     int32(frame.Line),
+		Function: frame.Function,
+	}
+}
+
+// appendCallerLocation appends the caller location in the "file:line" form, using the current path display
+func appendCallerLocation(buff []byte, caller proto.LogCallerMessage) []byte {
+	buff = append(buff, callerFile(caller)...)
+	return appendCallerLine(buff, caller)
+}
+
+func appendCallerLine(buff []byte, caller proto.LogCallerMessage) []byte {
+	buff = append(buff, ':')
+	return strconv.AppendInt(buff, int64(caller.Line), 10)
+}
+
+// callerFile returns the caller file path, using the current path display
+func callerFile(caller proto.LogCallerMessage) string {
+	if GetCallerPathDisplay() == CallerLongPath {
+		return caller.File
+	}
+
+	return shortCallerFile(caller.File)
+}
+
+// callerFunction returns the caller function name, using the current path display
+func callerFunction(caller proto.LogCallerMessage) string {
+	if GetCallerPathDisplay() == CallerLongPath {
+		return caller.Function
+	}
+
+	return caller.Function[strings.LastIndexByte(caller.Function, '/')+1:]
+}
+
+// shortCallerFile keeps the last directory and the file name from the provided path
+func shortCallerFile(file string) string {
+	index := strings.LastIndexByte(file, '/')
+	if index < 0 {
+		return file
+	}
+
+	index = strings.LastIndexByte(file[:index], '/')
+
+	return file[index+1:]
+}
+
+func hasCaller(caller proto.LogCallerMessage) bool {
+	return len(caller.File) > 0
+}
+
+// getCaller returns the caller location of the provided log line or an empty one if it is not a CallerHandler
+func getCaller(line LogLineHandler) proto.LogCallerMessage {
+	callerLine, ok := line.(CallerHandler)
+	if !ok {
+		return proto.LogCallerMessage{}
+	}
+
+	return callerLine.GetCaller()
+}
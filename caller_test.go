@@ -0,0 +1,46 @@
+package logger
+
+import (
+	"testing"
+
+	"github.com/Dharitri-org/me-core-logger-go/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestToggleCaller(t *testing.T) {
+	ToggleCaller(true)
+	require.True(t, IsEnabledCaller())
+
+	ToggleCaller(false)
+	require.False(t, IsEnabledCaller())
+	assert.Equal(t, proto.LogCallerMessage{}, captureCaller(1))
+}
+
+func TestCallerPathDisplay(t *testing.T) {
+	defer SetCallerPathDisplay(CallerShortPath)
+
+	caller := proto.LogCallerMessage{
+		File:     "/go/src/process/block.go",
+		Line:     12,
+		Function: "github.com/multiversx/process.(*block).Process",
+	}
+
+	SetCallerPathDisplay(CallerShortPath)
+	assert.Equal(t, "process/block.go:12", string(appendCallerLocation(nil, caller)))
+	assert.Equal(t, "process.(*block).Process", callerFunction(caller))
+
+	SetCallerPathDisplay(CallerLongPath)
+	assert.Equal(t, CallerLongPath, GetCallerPathDisplay())
+	assert.Equal(t, "/go/src/process/block.go:12", string(appendCallerLocation(nil, caller)))
+	assert.Equal(t, "github.com/multiversx/process.(*block).Process", callerFunction(caller))
+}
+
+func TestShortCallerFile(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "block.go", shortCallerFile("block.go"))
+	assert.Equal(t, "/block.go", shortCallerFile("/block.go"))
+	assert.Equal(t, "process/block.go", shortCallerFile("process/block.go"))
+	assert.Equal(t, "process/block.go", shortCallerFile("/go/src/process/block.go"))
+}
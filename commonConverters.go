@@ -2,24 +2,12 @@ package logger
 
 import (
 	"encoding/hex"
-	"fmt"
 	"strings"
-	"time"
-
-	"github.com/Dharitri-org/me-core-logger-go/proto"
 )
 
-const bracketsLength = len("[]")
-const loggerNameFixedLength = 20
-const correlationElementsFixedLength = 14
 const messageFixedLength = 40
 const ellipsisString = ".."
 
-func displayTime(timestamp int64) string {
-	t := time.Unix(0, timestamp)
-	return t.Format("2006-01-02 15:04:05.000")
-}
-
 func formatMessage(msg string) string {
 	return padRight(msg, messageFixedLength)
 }
@@ -34,32 +22,6 @@ func padRight(str string, maxLength int) string {
 	return str
 }
 
-func formatLoggerName(name string) string {
-	name = truncatePrefix(name, loggerNameFixedLength-bracketsLength)
-	formattedName := fmt.Sprintf("[%s]", name)
-
-	return padRight(formattedName, loggerNameFixedLength)
-}
-
-func truncatePrefix(str string, maxLength int) string {
-	if len(str) > maxLength {
-		startingIndex := len(str) - maxLength + len(ellipsisString)
-		return ellipsisString + str[startingIndex:]
-	}
-
-	return str
-}
-
-func formatCorrelationElements(correlation proto.LogCorrelationMessage) string {
-	shard := correlation.GetShard()
-	epoch := correlation.GetEpoch()
-	round := correlation.GetRound()
-	subRound := correlation.GetSubRound()
-	formattedElements := fmt.Sprintf("[%s/%d/%d/%s]", shard, epoch, round, subRound)
-
-	return padRight(formattedElements, correlationElementsFixedLength)
-}
-
 // ToHexShort generates a short-hand of provided bytes slice showing only the first 3 and the last 3 bytes as hex
 // in total, the resulting string is maximum 13 characters long
 func ToHexShort(slice []byte) string {
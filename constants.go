@@ -1,4 +1,7 @@
 package logger
 
-const formatColoredString = "\033[%s%s\033[0m[%s] %s %s %s %s\n"
-const formatPlainString = "%s[%s] %s %s %s %s\n"
+// ConsoleTemplate is the PatternFormatter template used by the ConsoleFormatter
+const ConsoleTemplate = "%level{-5|color}[%time] %logger{brackets|-20.18} %corr{brackets|-14} %caller{brackets|space}%msg{-40} %args{color}\n%stack"
+
+// PlainTemplate is the PatternFormatter template used by the PlainFormatter
+const PlainTemplate = "%level{-5}[%time] %logger{brackets|-20.18} %corr{brackets|-14} %caller{brackets|space}%msg{-40} %args\n%stack"
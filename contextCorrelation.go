@@ -0,0 +1,103 @@
+package logger
+
+import (
+	"context"
+
+	"github.com/Dharitri-org/me-core-logger-go/proto"
+)
+
+type correlationContextKey struct{}
+
+// contextCorrelation holds the correlation elements attached to a context. Only the elements that were set
+// will override the global correlation elements
+type contextCorrelation struct {
+	hasShard    bool
+	shard       string
+	hasEpoch    bool
+	epoch       uint32
+	hasRound    bool
+	round       int64
+	hasSubRound bool
+	subRound    string
+}
+
+func getContextCorrelation(ctx context.Context) contextCorrelation {
+	if ctx == nil {
+		return contextCorrelation{}
+	}
+
+	cc, _ := ctx.Value(correlationContextKey{}).(contextCorrelation)
+	return cc
+}
+
+func withContextCorrelation(ctx context.Context, update func(cc *contextCorrelation)) context.Context {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	cc := getContextCorrelation(ctx)
+	update(&cc)
+
+	return context.WithValue(ctx, correlationContextKey{}, cc)
+}
+
+// ContextWithCorrelation returns a copy of the provided context holding all the provided correlation elements.
+// The log lines produced with the returned context will use these elements instead of the global ones.
+func ContextWithCorrelation(ctx context.Context, correlation proto.LogCorrelationMessage) context.Context {
+	return withContextCorrelation(ctx, func(cc *contextCorrelation) {
+		cc.hasShard, cc.shard = true, correlation.Shard
+		cc.hasEpoch, cc.epoch = true, correlation.Epoch
+		cc.hasRound, cc.round = true, correlation.Round
+		cc.hasSubRound, cc.subRound = true, correlation.SubRound
+	})
+}
+
+// ContextWithCorrelationShard returns a copy of the provided context that overrides the shard ID correlation element
+func ContextWithCorrelationShard(ctx context.Context, shardID string) context.Context {
+	return withContextCorrelation(ctx, func(cc *contextCorrelation) {
+		cc.hasShard, cc.shard = true, shardID
+	})
+}
+
+// ContextWithCorrelationEpoch returns a copy of the provided context that overrides the epoch correlation element
+func ContextWithCorrelationEpoch(ctx context.Context, epoch uint32) context.Context {
+	return withContextCorrelation(ctx, func(cc *contextCorrelation) {
+		cc.hasEpoch, cc.epoch = true, epoch
+	})
+}
+
+// ContextWithCorrelationRound returns a copy of the provided context that overrides the round correlation element
+func ContextWithCorrelationRound(ctx context.Context, round int64) context.Context {
+	return withContextCorrelation(ctx, func(cc *contextCorrelation) {
+		cc.hasRound, cc.round = true, round
+	})
+}
+
+// ContextWithCorrelationSubround returns a copy of the provided context that overrides the sub-round correlation element
+func ContextWithCorrelationSubround(ctx context.Context, subRound string) context.Context {
+	return withContextCorrelation(ctx, func(cc *contextCorrelation) {
+		cc.hasSubRound, cc.subRound = true, subRound
+	})
+}
+
+// GetCorrelationFromContext gets the correlation elements attached to the provided context, falling back to
+// the global correlation elements for the ones that were not attached
+func GetCorrelationFromContext(ctx context.Context) proto.LogCorrelationMessage {
+	lcm := GetCorrelation()
+	cc := getContextCorrelation(ctx)
+
+	if cc.hasShard {
+		lcm.Shard = cc.shard
+	}
+	if cc.hasEpoch {
+		lcm.Epoch = cc.epoch
+	}
+	if cc.hasRound {
+		lcm.Round = cc.round
+	}
+	if cc.hasSubRound {
+		lcm.SubRound = cc.subRound
+	}
+
+	return lcm
+}
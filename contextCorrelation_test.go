@@ -0,0 +1,58 @@
+package logger
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Dharitri-org/me-core-logger-go/proto"
+	"github.com/stretchr/testify/require"
+)
+
+func TestContextCorrelation_NoElementsShouldFallBackToGlobal(t *testing.T) {
+	SetCorrelationShard("global-shard")
+	SetCorrelationEpoch(1)
+	SetCorrelationRound(2)
+	SetCorrelationSubround("global-subround")
+
+	require.Equal(t, GetCorrelation(), GetCorrelationFromContext(context.Background()))
+}
+
+func TestContextCorrelation_PartialOverrides(t *testing.T) {
+	SetCorrelationShard("global-shard")
+	SetCorrelationEpoch(1)
+	SetCorrelationRound(2)
+	SetCorrelationSubround("global-subround")
+
+	ctx := ContextWithCorrelationRound(context.Background(), 42)
+	ctx = ContextWithCorrelationSubround(ctx, "(START_ROUND)")
+
+	expected := proto.LogCorrelationMessage{
+		Shard:    "global-shard",
+		Epoch:    1,
+		Round:    42,
+		SubRound: "(START_ROUND)",
+	}
+	require.Equal(t, expected, GetCorrelationFromContext(ctx))
+
+	ctx = ContextWithCorrelationShard(ctx, "metachain")
+	ctx = ContextWithCorrelationEpoch(ctx, 7)
+	expected.Shard = "metachain"
+	expected.Epoch = 7
+	require.Equal(t, expected, GetCorrelationFromContext(ctx))
+}
+
+func TestContextCorrelation_FullOverrideShouldNotChangeTheParentContext(t *testing.T) {
+	SetCorrelationShard("global-shard")
+
+	parent := ContextWithCorrelationShard(context.Background(), "0")
+	correlation := proto.LogCorrelationMessage{
+		Shard:    "1",
+		Epoch:    2,
+		Round:    3,
+		SubRound: "4",
+	}
+	child := ContextWithCorrelation(parent, correlation)
+
+	require.Equal(t, correlation, GetCorrelationFromContext(child))
+	require.Equal(t, "0", GetCorrelationFromContext(parent).Shard)
+}
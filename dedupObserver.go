@@ -0,0 +1,199 @@
+package logger
+
+import (
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/Dharitri-org/me-core-logger-go/proto"
+	"github.com/Dharitri-org/me-core/core/check"
+)
+
+const dedupSummaryMessage = "last message repeated %d times"
+const dedupSummaryKeyFirst = "first"
+const dedupSummaryKeyLast = "last"
+
+var _ LogLineObserver = (*DedupObserver)(nil)
+var _ Flusher = (*DedupObserver)(nil)
+
+// ArgsDedupObserver is the argument used to create a new DedupObserver
+type ArgsDedupObserver struct {
+	Writer    io.Writer
+	Formatter Formatter
+	Timeout   time.Duration
+}
+
+// DedupObserver wraps a writer + formatter pair and collapses the identical consecutive log lines (same logger,
+// level, message and arguments). The first line of a run is written as it is, the following ones being counted.
+// When the run ends (a different log line arrives), when the timeout passes since the first counted line or when
+// Flush is called, a "last message repeated N times" line is written, having the logger and the level of the
+// repeated line and holding the timestamps of the first and the last counted occurrences. The summary line is
+// timestamped with the last occurrence. A zero timeout disables the timed summaries.
+// The DedupObserver should be added with RegisterLogLineObserver.
+type DedupObserver struct {
+	mut        sync.Mutex
+	writer     io.Writer
+	formatter  Formatter
+	timeout    time.Duration
+	lastLine   *LogLineWrapper
+	numRepeats int
+	firstTime  int64
+	lastTime   int64
+	timer      *time.Timer
+}
+
+// NewDedupObserver creates a new DedupObserver around the provided writer + formatter pair
+func NewDedupObserver(args ArgsDedupObserver) (*DedupObserver, error) {
+	if args.Writer == nil {
+		return nil, ErrNilWriter
+	}
+	if check.IfNil(args.Formatter) {
+		return nil, ErrNilFormatter
+	}
+	if args.Timeout < 0 {
+		return nil, ErrInvalidDedupTimeout
+	}
+
+	return &DedupObserver{
+		writer:    args.Writer,
+		formatter: args.Formatter,
+		timeout:   args.Timeout,
+	}, nil
+}
+
+// OutputLogLine writes the formatted log line, preceded by the summary of the ended run, if any. The repeated log
+// lines are only counted
+func (do *DedupObserver) OutputLogLine(line LogLineHandler) error {
+	if check.IfNil(line) {
+		return nil
+	}
+
+	do.mut.Lock()
+	defer do.mut.Unlock()
+
+	if do.isRepeated(line) {
+		do.countRepeat(line.GetTimestamp())
+		return nil
+	}
+
+	buff := do.summaryOutput()
+	buff = append(buff, formatLogLine(do.formatter, line)...)
+	do.lastLine = CloneLogLine(line)
+	if len(buff) == 0 {
+		return nil
+	}
+
+	_, err := do.writer.Write(buff)
+
+	return err
+}
+
+// Flush writes the summary of the current run, if any
+func (do *DedupObserver) Flush() error {
+	do.mut.Lock()
+	defer do.mut.Unlock()
+
+	return do.writeSummary()
+}
+
+func (do *DedupObserver) isRepeated(line LogLineHandler) bool {
+	if do.lastLine == nil {
+		return false
+	}
+	if do.lastLine.LoggerName != line.GetLoggerName() || do.lastLine.LogLevel != line.GetLogLevel() {
+		return false
+	}
+	if do.lastLine.Message != line.GetMessage() {
+		return false
+	}
+
+	args := line.GetArgs()
+	if len(do.lastLine.Args) != len(args) {
+		return false
+	}
+	for i, arg := range args {
+		if do.lastLine.Args[i] != arg {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (do *DedupObserver) countRepeat(timestamp int64) {
+	if do.numRepeats == 0 {
+		do.firstTime = timestamp
+		do.startTimer()
+	}
+	do.numRepeats++
+	do.lastTime = timestamp
+}
+
+func (do *DedupObserver) startTimer() {
+	if do.timeout == 0 {
+		return
+	}
+
+	if do.timer == nil {
+		do.timer = time.AfterFunc(do.timeout, do.onTimeout)
+		return
+	}
+	do.timer.Reset(do.timeout)
+}
+
+func (do *DedupObserver) onTimeout() {
+	do.mut.Lock()
+	defer do.mut.Unlock()
+
+	_ = do.writeSummary()
+}
+
+func (do *DedupObserver) writeSummary() error {
+	buff := do.summaryOutput()
+	if len(buff) == 0 {
+		return nil
+	}
+
+	_, err := do.writer.Write(buff)
+
+	return err
+}
+
+// summaryOutput returns the formatted summary of the current run, if any, and resets the repeats counter. The last
+// log line is kept so a run continuing after a timed summary is counted again
+func (do *DedupObserver) summaryOutput() []byte {
+	if do.numRepeats == 0 {
+		return nil
+	}
+
+	if do.timer != nil {
+		do.timer.Stop()
+	}
+
+	summary := &LogLineWrapper{
+		LogLineMessage: proto.LogLineMessage{
+			LoggerName:  do.lastLine.LoggerName,
+			Correlation: do.lastLine.Correlation,
+			Message:     fmt.Sprintf(dedupSummaryMessage, do.numRepeats),
+			LogLevel:    do.lastLine.LogLevel,
+			Args: []string{
+				dedupSummaryKeyFirst, formatDedupTimestamp(do.firstTime),
+				dedupSummaryKeyLast, formatDedupTimestamp(do.lastTime),
+			},
+			Timestamp: do.lastTime,
+		},
+	}
+	do.numRepeats = 0
+
+	return do.formatter.Output(summary)
+}
+
+func formatDedupTimestamp(timestamp int64) string {
+	return time.Unix(0, timestamp).Format(time.RFC3339Nano)
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (do *DedupObserver) IsInterfaceNil() bool {
+	return do == nil
+}
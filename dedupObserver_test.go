@@ -0,0 +1,202 @@
+package logger_test
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	logger "github.com/Dharitri-org/me-core-logger-go"
+	"github.com/Dharitri-org/me-core-logger-go/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type syncBuffer struct {
+	mut  sync.Mutex
+	buff bytes.Buffer
+}
+
+func (sb *syncBuffer) Write(p []byte) (int, error) {
+	sb.mut.Lock()
+	defer sb.mut.Unlock()
+
+	return sb.buff.Write(p)
+}
+
+func (sb *syncBuffer) String() string {
+	sb.mut.Lock()
+	defer sb.mut.Unlock()
+
+	return sb.buff.String()
+}
+
+// lineObserverTestTemplate is the pattern used to format the log lines written by the log line observers under test
+const lineObserverTestTemplate = "%level %logger %msg %args\n"
+
+// createLineObserverTestOutput registers the provided log line observer on a new log output subject
+func createLineObserverTestOutput(t *testing.T, observer logger.LogLineObserver) logger.LogOutputHandler {
+	los := logger.NewLogOutputSubject()
+	_, err := los.RegisterLogLineObserver(observer, logger.ObserverOptions{MinLevel: logger.LogTrace})
+	require.Nil(t, err)
+
+	return los
+}
+
+// splitLines returns the written log lines, without their trailing new line
+func splitLines(written string) []string {
+	if len(written) == 0 {
+		return make([]string, 0)
+	}
+
+	return strings.Split(strings.TrimSuffix(written, "\n"), "\n")
+}
+
+func TestNewDedupObserver_InvalidArgsShouldErr(t *testing.T) {
+	t.Parallel()
+
+	dedup, err := logger.NewDedupObserver(logger.ArgsDedupObserver{Formatter: &mock.FormatterStub{}})
+	assert.Nil(t, dedup)
+	assert.Equal(t, logger.ErrNilWriter, err)
+
+	dedup, err = logger.NewDedupObserver(logger.ArgsDedupObserver{Writer: &bytes.Buffer{}})
+	assert.Nil(t, dedup)
+	assert.Equal(t, logger.ErrNilFormatter, err)
+
+	dedup, err = logger.NewDedupObserver(logger.ArgsDedupObserver{
+		Writer:    &bytes.Buffer{},
+		Formatter: &mock.FormatterStub{},
+		Timeout:   -time.Second,
+	})
+	assert.Nil(t, dedup)
+	assert.True(t, errors.Is(err, logger.ErrInvalidDedupTimeout))
+}
+
+func TestDedupObserver_ShouldCollapseRepeatedLines(t *testing.T) {
+	t.Parallel()
+
+	writer := &syncBuffer{}
+	dedup, err := logger.NewDedupObserver(logger.ArgsDedupObserver{
+		Writer:    writer,
+		Formatter: createPatternFormatter(t, lineObserverTestTemplate),
+	})
+	require.Nil(t, err)
+	log := logger.NewLogger("sync", logger.LogTrace, createLineObserverTestOutput(t, dedup))
+
+	for i := 0; i < 4; i++ {
+		log.Debug("syncing", "nonce", 7)
+	}
+	log.Debug("syncing", "nonce", 8)
+	log.Info("syncing", "nonce", 8)
+	log.Info("syncing", "nonce", 8)
+	require.Nil(t, dedup.Flush())
+	require.Nil(t, dedup.Flush())
+
+	lines := splitLines(writer.String())
+	require.Equal(t, 5, len(lines))
+	assert.Equal(t, "DEBUG sync syncing nonce = 7 ", lines[0])
+	assert.True(t, strings.HasPrefix(lines[1], "DEBUG sync last message repeated 3 times first = "), lines[1])
+	assert.Contains(t, lines[1], " last = ")
+	assert.Equal(t, "DEBUG sync syncing nonce = 8 ", lines[2])
+	assert.Equal(t, "INFO sync syncing nonce = 8 ", lines[3])
+	assert.True(t, strings.HasPrefix(lines[4], "INFO sync last message repeated 1 times"), lines[4])
+}
+
+func TestDedupObserver_SummaryShouldHoldTheFirstAndLastTimestamps(t *testing.T) {
+	t.Parallel()
+
+	var summary logger.LogLineHandler
+	formatter := &mock.FormatterStub{
+		OutputCalled: func(line logger.LogLineHandler) []byte {
+			if strings.HasPrefix(line.GetMessage(), "last message repeated") {
+				summary = line
+			}
+			return []byte(line.GetMessage())
+		},
+	}
+	writer := &bytes.Buffer{}
+	dedup, err := logger.NewDedupObserver(logger.ArgsDedupObserver{
+		Writer:    writer,
+		Formatter: formatter,
+	})
+	require.Nil(t, err)
+
+	line := createPatternTestLogLine("sync", "syncing", logger.LogDebug)
+	require.Nil(t, dedup.OutputLogLine(line))
+	assert.Equal(t, "syncing", writer.String())
+	first := time.Date(2023, 1, 2, 3, 4, 5, 6, time.UTC)
+	for i := 0; i < 3; i++ {
+		line.Timestamp = first.Add(time.Duration(i) * time.Second).UnixNano()
+		require.Nil(t, dedup.OutputLogLine(line))
+	}
+	assert.Equal(t, "syncing", writer.String())
+
+	other := createPatternTestLogLine("sync", "synced", logger.LogDebug)
+	require.Nil(t, dedup.OutputLogLine(other))
+	assert.Equal(t, "syncinglast message repeated 3 timessynced", writer.String())
+	require.NotNil(t, summary)
+	expectedArgs := []string{
+		"first", first.Local().Format(time.RFC3339Nano),
+		"last", first.Add(2 * time.Second).Local().Format(time.RFC3339Nano),
+	}
+	assert.Equal(t, expectedArgs, summary.GetArgs())
+	assert.Equal(t, line.Timestamp, summary.GetTimestamp())
+	assert.Equal(t, int32(logger.LogDebug), summary.GetLogLevel())
+	assert.Equal(t, "sync", summary.GetLoggerName())
+}
+
+func TestDedupObserver_ShouldWriteTheSummaryOnTimeout(t *testing.T) {
+	t.Parallel()
+
+	writer := &syncBuffer{}
+	dedup, err := logger.NewDedupObserver(logger.ArgsDedupObserver{
+		Writer:    writer,
+		Formatter: createPatternFormatter(t, lineObserverTestTemplate),
+		Timeout:   10 * time.Millisecond,
+	})
+	require.Nil(t, err)
+	log := logger.NewLogger("sync", logger.LogTrace, createLineObserverTestOutput(t, dedup))
+
+	for i := 0; i < 3; i++ {
+		log.Warn("repeated")
+	}
+	assert.Eventually(t, func() bool {
+		return strings.Contains(writer.String(), "WARN sync last message repeated 2 times")
+	}, time.Second, time.Millisecond)
+
+	log.Warn("repeated")
+	log.Warn("other")
+	lines := splitLines(writer.String())
+	require.Equal(t, 4, len(lines))
+	assert.True(t, strings.HasPrefix(lines[2], "WARN sync last message repeated 1 times"), lines[2])
+	assert.Equal(t, "WARN sync other ", lines[3])
+}
+
+func TestDedupObserver_CustomFormatterCanRetainTheLogLines(t *testing.T) {
+	t.Parallel()
+
+	lines := make([]logger.LogLineHandler, 0)
+	dedup, err := logger.NewDedupObserver(logger.ArgsDedupObserver{
+		Writer: &bytes.Buffer{},
+		Formatter: &mock.FormatterStub{
+			OutputCalled: func(line logger.LogLineHandler) []byte {
+				lines = append(lines, line)
+				return nil
+			},
+		},
+	})
+	require.Nil(t, err)
+	log := logger.NewLogger("sync", logger.LogTrace, createLineObserverTestOutput(t, dedup))
+
+	log.Info("first", "a", 1)
+	log.Info("second", "b", 2)
+	log.Info("third", "c", 3)
+
+	require.Equal(t, 3, len(lines))
+	assert.Equal(t, "first", lines[0].GetMessage())
+	assert.Equal(t, []string{"a", "1"}, lines[0].GetArgs())
+	assert.Equal(t, "second", lines[1].GetMessage())
+	assert.Equal(t, []string{"b", "2"}, lines[1].GetArgs())
+}
@@ -8,6 +8,9 @@ var ErrNilWriter = errors.New("nil writer provided")
 // ErrNilFormatter signals that a nil formatter has been provided
 var ErrNilFormatter = errors.New("nil formatter provided")
 
+// ErrNilLogLineObserver signals that a nil log line observer has been provided
+var ErrNilLogLineObserver = errors.New("nil log line observer provided")
+
 // ErrInvalidLogLevelPattern signals that an un-parsable log level and patter was provided
 var ErrInvalidLogLevelPattern = errors.New("un-parsable log level and pattern provided")
 
@@ -19,3 +22,69 @@ var ErrNilMarshalizer = errors.New("nil marshalizer")
 
 // ErrNilDisplayByteSliceHandler signals that a nil display byte slice handler has been provided
 var ErrNilDisplayByteSliceHandler = errors.New("nil display byte slice handler")
+
+var errMissingLogLevel = errors.New("missing ':' separator between the matching string and the log level")
+
+// ErrNilSlogHandler signals that a nil slog handler has been provided
+var ErrNilSlogHandler = errors.New("nil slog handler")
+
+// ErrLoggerAlreadyExists signals that a logger with the provided name already exists
+var ErrLoggerAlreadyExists = errors.New("logger already exists")
+
+// ErrInvalidQueueSize signals that an invalid queue size has been provided
+var ErrInvalidQueueSize = errors.New("invalid queue size")
+
+// ErrInvalidOverflowPolicy signals that an invalid overflow policy has been provided
+var ErrInvalidOverflowPolicy = errors.New("invalid overflow policy")
+
+// ErrAsyncOutputAlreadyEnabled signals that the asynchronous output mode is already enabled
+var ErrAsyncOutputAlreadyEnabled = errors.New("asynchronous output already enabled")
+
+// ErrObserverQueuesAlreadyEnabled signals that the observers queues were already enabled
+var ErrObserverQueuesAlreadyEnabled = errors.New("observers queues already enabled")
+
+// ErrInvalidLogfmtLine signals that an un-parsable logfmt line was provided
+var ErrInvalidLogfmtLine = errors.New("un-parsable logfmt line provided")
+
+var errUnterminatedQuotedValue = errors.New("unterminated quoted value")
+
+// ErrNilExitHook signals that a nil exit hook has been provided
+var ErrNilExitHook = errors.New("nil exit hook")
+
+// ErrNilExitHandler signals that a nil exit handler has been provided
+var ErrNilExitHandler = errors.New("nil exit handler")
+
+// ErrObserverNotFound signals that no observer has the provided ID
+var ErrObserverNotFound = errors.New("observer not found")
+
+// ErrObserverDisabled signals that an observer was disabled by its write error policy
+var ErrObserverDisabled = errors.New("observer disabled")
+
+var errMissingCallerLine = errors.New("missing caller line number")
+
+// ErrInvalidPatternTemplate signals that an un-parsable pattern formatter template was provided
+var ErrInvalidPatternTemplate = errors.New("un-parsable pattern formatter template provided")
+
+// ErrInvalidSamplingPattern signals that an un-parsable sampling pattern was provided
+var ErrInvalidSamplingPattern = errors.New("un-parsable sampling pattern provided")
+
+// ErrInvalidRingBufferSize signals that invalid ring buffer limits have been provided
+var ErrInvalidRingBufferSize = errors.New("invalid ring buffer size")
+
+// ErrMissingDumpSignals signals that no signals have been provided for the ring buffer dumps
+var ErrMissingDumpSignals = errors.New("missing dump signals")
+
+// ErrInvalidTriggerLevel signals that a trigger level lower than the buffering level has been provided
+var ErrInvalidTriggerLevel = errors.New("invalid trigger level")
+
+// ErrInvalidBufferSize signals that an invalid buffer size has been provided
+var ErrInvalidBufferSize = errors.New("invalid buffer size")
+
+// ErrInvalidMaxBufferedKeys signals that an invalid maximum number of buffered keys has been provided
+var ErrInvalidMaxBufferedKeys = errors.New("invalid maximum number of buffered keys")
+
+// ErrInvalidBufferTimeout signals that an invalid buffer timeout has been provided
+var ErrInvalidBufferTimeout = errors.New("invalid buffer timeout")
+
+// ErrInvalidDedupTimeout signals that an invalid deduplication timeout has been provided
+var ErrInvalidDedupTimeout = errors.New("invalid deduplication timeout")
@@ -0,0 +1,105 @@
+package logger
+
+import (
+	"io"
+	"os"
+	"sync"
+)
+
+const fatalExitCode = 1
+
+var globalExit exitSettings
+
+// exitSettings holds the functions called by the Panic and Fatal methods
+type exitSettings struct {
+	mut     sync.RWMutex
+	hooks   []func()
+	handler func(code int)
+}
+
+func init() {
+	globalExit.hooks = make([]func(), 0)
+	globalExit.handler = os.Exit
+}
+
+// RegisterExitHook registers a function called by the Panic and Fatal methods after outputting their log line and
+// before flushing the outputs and panicking or ending the process. The hooks are called in their registration order,
+// a panicking hook not preventing the next ones from being called
+func RegisterExitHook(hook func()) error {
+	if hook == nil {
+		return ErrNilExitHook
+	}
+
+	globalExit.mut.Lock()
+	globalExit.hooks = append(globalExit.hooks, hook)
+	globalExit.mut.Unlock()
+
+	return nil
+}
+
+// SetExitHandler sets the function called by the Fatal method in order to end the process, os.Exit being the
+// default one. It can be used by the tests that need to check the Fatal behavior
+func SetExitHandler(handler func(code int)) error {
+	if handler == nil {
+		return ErrNilExitHandler
+	}
+
+	globalExit.mut.Lock()
+	globalExit.handler = handler
+	globalExit.mut.Unlock()
+
+	return nil
+}
+
+func runExitHooks() {
+	globalExit.mut.RLock()
+	hooks := make([]func(), len(globalExit.hooks))
+	copy(hooks, globalExit.hooks)
+	globalExit.mut.RUnlock()
+
+	for _, hook := range hooks {
+		runExitHook(hook)
+	}
+}
+
+func runExitHook(hook func()) {
+	defer func() {
+		_ = recover()
+	}()
+
+	hook()
+}
+
+func exitProcess(code int) {
+	globalExit.mut.RLock()
+	handler := globalExit.handler
+	globalExit.mut.RUnlock()
+
+	handler(code)
+}
+
+// prepareExit runs the exit hooks, outputs the pending sampling summaries and flushes the provided output along with
+// the default log output subject. If closeOutputs is set, the outputs are closed instead, so the observers' writers
+// added with the CloseOnRemove option, such as the log files, are also closed
+func prepareExit(output logLineOutput, closeOutputs bool) {
+	runExitHooks()
+	outputAllSamplingSummaries(true)
+
+	finishOutput(output, closeOutputs)
+	if output != logLineOutput(defaultLogOut) {
+		finishOutput(defaultLogOut, closeOutputs)
+	}
+}
+
+func finishOutput(output logLineOutput, closeOutput bool) {
+	outputCloser, ok := output.(io.Closer)
+	if closeOutput && ok {
+		_ = outputCloser.Close()
+		return
+	}
+
+	outputFlusher, ok := output.(Flusher)
+	if ok {
+		_ = outputFlusher.Flush()
+	}
+}
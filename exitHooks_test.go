@@ -0,0 +1,173 @@
+package logger
+
+import (
+	"context"
+	"os"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/Dharitri-org/me-core-logger-go/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type flushingWriterStub struct {
+	mut        sync.Mutex
+	operations []string
+}
+
+func (fws *flushingWriterStub) Write(p []byte) (int, error) {
+	fws.record("write " + string(p))
+	return len(p), nil
+}
+
+func (fws *flushingWriterStub) Flush() error {
+	fws.record("flush")
+	return nil
+}
+
+func (fws *flushingWriterStub) Close() error {
+	fws.record("close")
+	return nil
+}
+
+func (fws *flushingWriterStub) record(operation string) {
+	fws.mut.Lock()
+	fws.operations = append(fws.operations, operation)
+	fws.mut.Unlock()
+}
+
+func setExitTestHooks(t *testing.T, writer *flushingWriterStub) {
+	globalExit.mut.Lock()
+	globalExit.hooks = make([]func(), 0)
+	globalExit.mut.Unlock()
+
+	require.Nil(t, RegisterExitHook(func() {
+		writer.record("hook 1")
+		panic("hook panic")
+	}))
+	require.Nil(t, RegisterExitHook(func() {
+		writer.record("hook 2")
+	}))
+	require.Nil(t, SetExitHandler(func(code int) {
+		writer.record("exit " + strconv.Itoa(code))
+	}))
+}
+
+func resetExitTestHooks() {
+	globalExit.mut.Lock()
+	globalExit.hooks = make([]func(), 0)
+	globalExit.handler = os.Exit
+	globalExit.mut.Unlock()
+}
+
+// replaceDefaultLogOut replaces the default log output subject, as the Fatal method closes it, returning the function
+// restoring the original one
+func replaceDefaultLogOut() func() {
+	original := defaultLogOut
+	defaultLogOut = NewLogOutputSubject()
+
+	return func() {
+		defaultLogOut = original
+	}
+}
+
+func createExitTestLogger(t *testing.T, writer *flushingWriterStub, template string, options ObserverOptions) *logger {
+	pf, err := NewPatternFormatter(ArgsPatternFormatter{Template: template, Correlation: FieldShown})
+	require.Nil(t, err)
+
+	los := NewLogOutputSubject()
+	require.Nil(t, los.AddObserverWithOptions(writer, pf, options))
+
+	return NewLogger("test", LogNone, los)
+}
+
+func TestExitHooks_NilArgumentsShouldErr(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, ErrNilExitHook, RegisterExitHook(nil))
+	assert.Equal(t, ErrNilExitHandler, SetExitHandler(nil))
+}
+
+func TestLogger_FatalShouldRunTheHooksFlushAndExit(t *testing.T) {
+	defer resetExitTestHooks()
+	defer replaceDefaultLogOut()()
+
+	writer := &flushingWriterStub{}
+	setExitTestHooks(t, writer)
+	log := createExitTestLogger(t, writer, "%level %msg", ObserverOptions{})
+
+	log.Error("not output")
+	log.Fatal("fatal message")
+
+	expected := []string{"write FATAL fatal message", "hook 1", "hook 2", "flush", "exit 1"}
+	assert.Equal(t, expected, writer.operations)
+}
+
+func TestLogger_FatalShouldCloseTheOutputs(t *testing.T) {
+	defer resetExitTestHooks()
+	defer replaceDefaultLogOut()()
+
+	writer := &flushingWriterStub{}
+	setExitTestHooks(t, writer)
+	log := createExitTestLogger(t, writer, "%level %corr %msg", ObserverOptions{CloseOnRemove: true})
+	defaultWriter := &flushingWriterStub{}
+	require.Nil(t, defaultLogOut.AddObserverWithOptions(defaultWriter, &PlainFormatter{}, ObserverOptions{CloseOnRemove: true}))
+
+	ctx := ContextWithCorrelation(context.Background(), proto.LogCorrelationMessage{Shard: "1", Epoch: 2, Round: 7, SubRound: "3"})
+	log.FatalCtx(ctx, "fatal message")
+
+	expected := []string{"write FATAL 1/2/7/3 fatal message", "hook 1", "hook 2", "flush", "close", "exit 1"}
+	assert.Equal(t, expected, writer.operations)
+	assert.Equal(t, []string{"flush", "close"}, defaultWriter.operations)
+	assert.Equal(t, 0, len(defaultLogOut.loadObservers()))
+}
+
+func TestLogger_PanicShouldRunTheHooksFlushAndPanic(t *testing.T) {
+	defer resetExitTestHooks()
+
+	writer := &flushingWriterStub{}
+	setExitTestHooks(t, writer)
+	log := createExitTestLogger(t, writer, "%level %msg", ObserverOptions{CloseOnRemove: true})
+
+	assert.PanicsWithValue(t, "panic message", func() {
+		log.Panic("panic message", "key", "value")
+	})
+
+	expected := []string{"write PANIC panic message", "hook 1", "hook 2", "flush"}
+	assert.Equal(t, expected, writer.operations)
+}
+
+func TestLogger_PanicCtxShouldNotCloseTheOutputs(t *testing.T) {
+	defer resetExitTestHooks()
+
+	writer := &flushingWriterStub{}
+	setExitTestHooks(t, writer)
+	log := createExitTestLogger(t, writer, "%level %corr %msg", ObserverOptions{CloseOnRemove: true})
+
+	ctx := ContextWithCorrelation(context.Background(), proto.LogCorrelationMessage{Shard: "1", Epoch: 2, Round: 7, SubRound: "3"})
+	assert.PanicsWithValue(t, "panic message", func() {
+		log.PanicCtx(ctx, "panic message")
+	})
+	log.SetLevel(LogError)
+	log.ErrorCtx(ctx, "after recover")
+
+	expected := []string{"write PANIC 1/2/7/3 panic message", "hook 1", "hook 2", "flush", "write ERROR 1/2/7/3 after recover"}
+	assert.Equal(t, expected, writer.operations)
+}
+
+func TestGetLogLevel_PanicAndFatal(t *testing.T) {
+	t.Parallel()
+
+	level, err := GetLogLevel("panic")
+	require.Nil(t, err)
+	assert.Equal(t, LogPanic, level)
+
+	level, err = GetLogLevel("FATAL")
+	require.Nil(t, err)
+	assert.Equal(t, LogFatal, level)
+
+	assert.Equal(t, ansiRegularMagenta, getLevelColor(LogPanic))
+	assert.Equal(t, ansiBoldRed, getLevelColor(LogFatal))
+}
@@ -6,15 +6,20 @@ var DefaultLogLevel = &defaultLogLevel
 
 // Observers -
 func (los *logOutputSubject) Observers() ([]io.Writer, []Formatter) {
-	los.mutObservers.RLock()
-	defer los.mutObservers.RUnlock()
+	observers := los.loadObservers()
+	writers := make([]io.Writer, 0, len(observers))
+	formatters := make([]Formatter, 0, len(observers))
+	for _, obs := range observers {
+		writers = append(writers, obs.writer)
+		formatters = append(formatters, obs.formatter)
+	}
 
-	return los.writers, los.formatters
+	return writers, formatters
 }
 
 // LogLevel -
 func (l *logger) LogLevel() LogLevel {
-	return l.logLevel
+	return l.GetLevel()
 }
 
 // IsASCII -
@@ -0,0 +1,248 @@
+package logger
+
+import (
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/Dharitri-org/me-core-logger-go/proto"
+)
+
+type fieldType byte
+
+const (
+	anyField fieldType = iota
+	stringField
+	int64Field
+	uint64Field
+	float64Field
+	boolField
+	bytesField
+	durationField
+	timeField
+	errorField
+)
+
+const nilDisplayValue = "<nil>"
+
+// Field is a typed key/value argument of a log line. The fields are created by the typed constructors
+// (String, Int64, Bytes, Err...) and are used by the *Fields logger methods that avoid the boxing of the
+// arguments into interface{} values
+type Field struct {
+	Key       string
+	fieldType fieldType
+	integer   int64
+	str       string
+	bytes     []byte
+	value     interface{}
+}
+
+// String creates a string field
+func String(key string, value string) Field {
+	return Field{Key: key, fieldType: stringField, str: value}
+}
+
+// Int creates an int field
+func Int(key string, value int) Field {
+	return Field{Key: key, fieldType: int64Field, integer: int64(value)}
+}
+
+// Int64 creates an int64 field
+func Int64(key string, value int64) Field {
+	return Field{Key: key, fieldType: int64Field, integer: value}
+}
+
+// Uint32 creates an uint32 field
+func Uint32(key string, value uint32) Field {
+	return Field{Key: key, fieldType: uint64Field, integer: int64(value)}
+}
+
+// Uint64 creates an uint64 field
+func Uint64(key string, value uint64) Field {
+	return Field{Key: key, fieldType: uint64Field, integer: int64(value)}
+}
+
+// Float64 creates a float64 field
+func Float64(key string, value float64) Field {
+	return Field{Key: key, fieldType: float64Field, integer: int64(math.Float64bits(value))}
+}
+
+// Bool creates a bool field
+func Bool(key string, value bool) Field {
+	field := Field{Key: key, fieldType: boolField}
+	if value {
+		field.integer = 1
+	}
+
+	return field
+}
+
+// Bytes creates a byte slice field, displayed with the configured display byte slice handler
+func Bytes(key string, value []byte) Field {
+	return Field{Key: key, fieldType: bytesField, bytes: value}
+}
+
+// Duration creates a time.Duration field
+func Duration(key string, value time.Duration) Field {
+	return Field{Key: key, fieldType: durationField, integer: int64(value)}
+}
+
+// Time creates a time.Time field. Only the instant is kept, the time being displayed in the local time zone
+func Time(key string, value time.Time) Field {
+	return Field{Key: key, fieldType: timeField, integer: value.UnixNano()}
+}
+
+// Err creates a field named "error" holding the provided error
+func Err(err error) Field {
+	return NamedErr("error", err)
+}
+
+// NamedErr creates an error field
+func NamedErr(key string, err error) Field {
+	return Field{Key: key, fieldType: errorField, value: err}
+}
+
+// Any creates a field holding a value of any type. The value is converted as the arguments of the non-typed
+// logger methods are, a LogValuer being resolved only when the log line is output
+func Any(key string, value interface{}) Field {
+	return Field{Key: key, fieldType: anyField, value: value}
+}
+
+// Value returns the value held by the field in its native type, resolving a LogValuer value
+func (f Field) Value() interface{} {
+	switch f.fieldType {
+	case stringField:
+		return f.str
+	case int64Field:
+		return f.integer
+	case uint64Field:
+		return uint64(f.integer)
+	case float64Field:
+		return math.Float64frombits(uint64(f.integer))
+	case boolField:
+		return f.integer == 1
+	case bytesField:
+		return f.bytes
+	case durationField:
+		return time.Duration(f.integer)
+	case timeField:
+		return time.Unix(0, f.integer)
+	default:
+		return resolveLogValue(f.value)
+	}
+}
+
+// resolved returns the field having its LogValuer value resolved
+func (f Field) resolved() Field {
+	if f.fieldType == anyField {
+		f.value = resolveLogValue(f.value)
+	}
+
+	return f
+}
+
+// displayValue returns the value of the field in its display form, as the arguments of the non-typed logger
+// methods are converted by the logOutputSubject
+func (f Field) displayValue(displayHandler func([]byte) string) string {
+	switch f.fieldType {
+	case stringField:
+		return convertStringIfNotASCII(displayHandler, f.str)
+	case int64Field:
+		return strconv.FormatInt(f.integer, 10)
+	case uint64Field:
+		return strconv.FormatUint(uint64(f.integer), 10)
+	case float64Field:
+		return strconv.FormatFloat(math.Float64frombits(uint64(f.integer)), 'g', -1, 64)
+	case boolField:
+		return strconv.FormatBool(f.integer == 1)
+	case bytesField:
+		return displayHandler(f.bytes)
+	case durationField:
+		return time.Duration(f.integer).String()
+	case timeField:
+		return time.Unix(0, f.integer).String()
+	case errorField:
+		err, _ := f.value.(error)
+		if err == nil {
+			return nilDisplayValue
+		}
+		return err.Error()
+	default:
+		return displayArgument(displayHandler, f.value)
+	}
+}
+
+// setLogArgument sets the field value on the provided typed argument, reusing its current value holder if possible
+func (f Field) setLogArgument(arg *proto.LogArgument) {
+	switch f.fieldType {
+	case stringField:
+		setStringLogArgument(arg, f.str)
+	case int64Field:
+		holder, ok := arg.Value.(*proto.LogArgument_Int64Value)
+		if !ok {
+			holder = &proto.LogArgument_Int64Value{}
+			arg.Value = holder
+		}
+		holder.Int64Value = f.integer
+	case uint64Field:
+		holder, ok := arg.Value.(*proto.LogArgument_Uint64Value)
+		if !ok {
+			holder = &proto.LogArgument_Uint64Value{}
+			arg.Value = holder
+		}
+		holder.Uint64Value = uint64(f.integer)
+	case float64Field:
+		holder, ok := arg.Value.(*proto.LogArgument_DoubleValue)
+		if !ok {
+			holder = &proto.LogArgument_DoubleValue{}
+			arg.Value = holder
+		}
+		holder.DoubleValue = math.Float64frombits(uint64(f.integer))
+	case boolField:
+		holder, ok := arg.Value.(*proto.LogArgument_BoolValue)
+		if !ok {
+			holder = &proto.LogArgument_BoolValue{}
+			arg.Value = holder
+		}
+		holder.BoolValue = f.integer == 1
+	case bytesField:
+		holder, ok := arg.Value.(*proto.LogArgument_BytesValue)
+		if !ok {
+			holder = &proto.LogArgument_BytesValue{}
+			arg.Value = holder
+		}
+		holder.BytesValue = append([]byte(nil), f.bytes...)
+	case durationField:
+		holder, ok := arg.Value.(*proto.LogArgument_DurationValue)
+		if !ok {
+			holder = &proto.LogArgument_DurationValue{}
+			arg.Value = holder
+		}
+		holder.DurationValue = f.integer
+	case timeField:
+		holder, ok := arg.Value.(*proto.LogArgument_TimestampValue)
+		if !ok {
+			holder = &proto.LogArgument_TimestampValue{}
+			arg.Value = holder
+		}
+		holder.TimestampValue = f.integer
+	case errorField:
+		err, _ := f.value.(error)
+		if err == nil {
+			arg.Value = nil
+			return
+		}
+		setStringLogArgument(arg, err.Error())
+	default:
+		*arg = newLogArgument(f.value)
+	}
+}
+
+func setStringLogArgument(arg *proto.LogArgument, value string) {
+	holder, ok := arg.Value.(*proto.LogArgument_StringValue)
+	if !ok {
+		holder = &proto.LogArgument_StringValue{}
+		arg.Value = holder
+	}
+	holder.StringValue = value
+}
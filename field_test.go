@@ -0,0 +1,145 @@
+package logger_test
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	logger "github.com/Dharitri-org/me-core-logger-go"
+	"github.com/Dharitri-org/me-core-logger-go/mock"
+	"github.com/Dharitri-org/me-core-logger-go/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestField_ValueShouldReturnTheNativeType(t *testing.T) {
+	t.Parallel()
+
+	timestamp := time.Unix(0, 1600000000123456789)
+	err := errors.New("expected error")
+	testData := []struct {
+		field    logger.Field
+		expected interface{}
+	}{
+		{logger.String("k", "value"), "value"},
+		{logger.Int("k", -1), int64(-1)},
+		{logger.Int64("k", math.MinInt64), int64(math.MinInt64)},
+		{logger.Uint32("k", math.MaxUint32), uint64(math.MaxUint32)},
+		{logger.Uint64("k", math.MaxUint64), uint64(math.MaxUint64)},
+		{logger.Float64("k", -2.5), -2.5},
+		{logger.Bool("k", true), true},
+		{logger.Bool("k", false), false},
+		{logger.Bytes("k", []byte("bytes")), []byte("bytes")},
+		{logger.Duration("k", time.Second), time.Second},
+		{logger.Err(err), err},
+		{logger.Any("k", struct{ A int }{A: 1}), struct{ A int }{A: 1}},
+	}
+
+	for _, td := range testData {
+		assert.Equal(t, td.expected, td.field.Value())
+	}
+	assert.True(t, timestamp.Equal(logger.Time("k", timestamp).Value().(time.Time)))
+	assert.Equal(t, "error", logger.Err(err).Key)
+	assert.Equal(t, "cause", logger.NamedErr("cause", err).Key)
+}
+
+func TestLogger_FieldsShouldConvertAsTheArguments(t *testing.T) {
+	t.Parallel()
+
+	los, lines := generateCapturingLogOutputSubject()
+	log := logger.NewLogger("test", logger.LogTrace, los)
+
+	log.InfoFields("message",
+		logger.String("string", "value"),
+		logger.Int("int", -1),
+		logger.Uint64("uint64", 2),
+		logger.Float64("float64", 1.5),
+		logger.Bool("bool", true),
+		logger.Bytes("bytes", []byte("pid")),
+		logger.Duration("duration", time.Second),
+		logger.Err(errors.New("expected error")),
+		logger.Err(nil),
+		logger.Any("any", []int{1, 2}),
+	)
+	log.Info("message",
+		"string", "value",
+		"int", -1,
+		"uint64", uint64(2),
+		"float64", 1.5,
+		"bool", true,
+		"bytes", []byte("pid"),
+		"duration", time.Second,
+		"error", "expected error",
+		"error", nil,
+		"any", []int{1, 2},
+	)
+
+	require.Equal(t, 2, len(*lines))
+	assert.Equal(t, (*lines)[1].GetArgs(), (*lines)[0].GetArgs())
+	typedArgs := (*lines)[0].(logger.TypedArgsHandler).GetTypedArgs()
+	require.Equal(t, 20, len(typedArgs))
+	assert.Equal(t, "string", logger.LogArgumentValue(typedArgs[0]))
+	assert.Equal(t, int64(-1), logger.LogArgumentValue(typedArgs[3]))
+	assert.Equal(t, uint64(2), logger.LogArgumentValue(typedArgs[5]))
+	assert.Equal(t, 1.5, logger.LogArgumentValue(typedArgs[7]))
+	assert.Equal(t, true, logger.LogArgumentValue(typedArgs[9]))
+	assert.Equal(t, []byte("pid"), logger.LogArgumentValue(typedArgs[11]))
+	assert.Equal(t, time.Second, logger.LogArgumentValue(typedArgs[13]))
+	assert.Equal(t, "expected error", logger.LogArgumentValue(typedArgs[15]))
+	assert.Nil(t, logger.LogArgumentValue(typedArgs[17]))
+	assert.Equal(t, "[1 2]", logger.LogArgumentValue(typedArgs[19]))
+}
+
+func TestLogger_FieldsShouldBeAppendedAfterTheBoundArgs(t *testing.T) {
+	t.Parallel()
+
+	los, lines := generateCapturingLogOutputSubject()
+	log := logger.NewLogger("test", logger.LogDebug, los)
+
+	derived := log.With("shard", 1).(logger.FieldsLogger)
+	derived.DebugFields("message", logger.Int("a", 2))
+	derived.TraceFields("not written", logger.Int("a", 3))
+	derived.LogFields(logger.LogWarning, "message", logger.String("b", "c"), logger.Int("d", 4))
+	log.ErrorFields("message")
+
+	require.Equal(t, 3, len(*lines))
+	assert.Equal(t, []string{"shard", "1", "a", "2"}, (*lines)[0].GetArgs())
+	assert.Equal(t, int32(logger.LogDebug), (*lines)[0].GetLogLevel())
+	assert.Equal(t, []string{"shard", "1", "b", "c", "d", "4"}, (*lines)[1].GetArgs())
+	assert.Equal(t, int32(logger.LogWarning), (*lines)[1].GetLogLevel())
+	assert.Equal(t, 0, len((*lines)[2].GetArgs()))
+	assert.Equal(t, int32(logger.LogError), (*lines)[2].GetLogLevel())
+}
+
+func TestLogger_BytesFieldShouldNotChangeWhenTheSliceIsChangedAfterTheCall(t *testing.T) {
+	t.Parallel()
+
+	los := logger.NewLogOutputSubject()
+	bo := newBlockingObserver(los)
+	typedArgs := make([]proto.LogArgument, 0)
+	_ = los.AddObserver(&mock.WriterStub{
+		WriteCalled: func(p []byte) (n int, err error) {
+			return len(p), nil
+		},
+	}, &mock.FormatterStub{
+		OutputCalled: func(line logger.LogLineHandler) []byte {
+			typedArgs = append(typedArgs, line.(logger.TypedArgsHandler).GetTypedArgs()...)
+			return nil
+		},
+	})
+	err := los.EnableAsync(logger.ArgsAsyncOutput{QueueSize: 10})
+	require.Nil(t, err)
+	log := logger.NewLogger("test", logger.LogTrace, los)
+
+	log.Info("first")
+	<-bo.chWriteStart
+	buff := []byte("AAAA")
+	log.InfoFields("message", logger.Bytes("hash", buff))
+	copy(buff, "ZZ")
+	close(bo.chUnblock)
+	los.DisableAsync()
+
+	require.Equal(t, 2, len(typedArgs))
+	assert.Equal(t, []byte("AAAA"), logger.LogArgumentValue(typedArgs[1]))
+}
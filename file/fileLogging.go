@@ -2,6 +2,7 @@ package file
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -108,7 +109,7 @@ func (fl *fileLogging) recreateLogFile() {
 	defer fl.mutOperation.Unlock()
 
 	oldFile := fl.currentFile
-	err = logger.AddLogObserver(newFile, &logger.PlainFormatter{})
+	err = logger.AddLogObserverWithOptions(newFile, &logger.PlainFormatter{}, logger.ObserverOptions{CloseOnRemove: true})
 	if err != nil {
 		log.Error("error adding log observer", "error", err)
 		return
@@ -123,9 +124,7 @@ func (fl *fileLogging) recreateLogFile() {
 		return
 	}
 
-	errNotCritical = oldFile.Close()
-	log.LogIfError(errNotCritical, "step", "closing old log file")
-
+	// the old file is closed by the removal, after its in-flight writes end
 	errNotCritical = logger.RemoveLogObserver(oldFile)
 	log.LogIfError(errNotCritical, "step", "removing old log observer")
 
@@ -217,7 +216,7 @@ func (fl *fileLogging) Close() error {
 	fl.mutIsClosed.Unlock()
 
 	fl.mutOperation.Lock()
-	err := fl.currentFile.Close()
+	err := fl.closeCurrentFile()
 	fl.mutOperation.Unlock()
 
 	fl.cancelFunc()
@@ -227,6 +226,22 @@ func (fl *fileLogging) Close() error {
 	return err
 }
 
+// closeCurrentFile removes the observer of the current file, closing it. The file is closed directly if its observer
+// was already removed, as it happens after the logger.Shutdown call
+func (fl *fileLogging) closeCurrentFile() error {
+	err := logger.RemoveLogObserver(fl.currentFile)
+	if !errors.Is(err, logger.ErrWriterNotFound) {
+		return err
+	}
+
+	err = fl.currentFile.Close()
+	if errors.Is(err, os.ErrClosed) {
+		return nil
+	}
+
+	return err
+}
+
 // IsInterfaceNil returns true if there is no value under the interface
 func (fl *fileLogging) IsInterfaceNil() bool {
 	return fl == nil
@@ -3,11 +3,13 @@ package file
 import (
 	"errors"
 	"io/ioutil"
+	"os"
 	"path/filepath"
 	"strings"
 	"testing"
 	"time"
 
+	logger "github.com/Dharitri-org/me-core-logger-go"
 	"github.com/Dharitri-org/me-core/core"
 	"github.com/Dharitri-org/me-core/core/check"
 	"github.com/stretchr/testify/assert"
@@ -147,3 +149,24 @@ func TestFileLogging_sizeReached(t *testing.T) {
 		assert.False(t, fl.sizeReached())
 	})
 }
+
+func TestFileLogging_RecreateAndCloseShouldRemoveTheObserversAndCloseTheFiles(t *testing.T) {
+	t.Parallel()
+
+	fl, err := NewFileLogging(createMockArgs(t))
+	require.Nil(t, err)
+
+	oldFile := fl.currentFile
+	fl.recreateLogFile()
+	require.NotEqual(t, oldFile, fl.currentFile)
+	_, err = oldFile.Write([]byte("data"))
+	assert.True(t, errors.Is(err, os.ErrClosed))
+	assert.Equal(t, logger.ErrWriterNotFound, logger.RemoveLogObserver(oldFile))
+
+	currentFile := fl.currentFile
+	err = fl.Close()
+	assert.Nil(t, err)
+	_, err = currentFile.Write([]byte("data"))
+	assert.True(t, errors.Is(err, os.ErrClosed))
+	assert.Equal(t, logger.ErrWriterNotFound, logger.RemoveLogObserver(currentFile))
+}
@@ -0,0 +1,270 @@
+package logger
+
+import (
+	"io"
+	"sync"
+	"time"
+
+	"github.com/Dharitri-org/me-core-logger-go/proto"
+	"github.com/Dharitri-org/me-core/core/check"
+)
+
+var _ LogLineObserver = (*FingersCrossedObserver)(nil)
+
+// CorrelationField selects a field of the log correlation used to group the log lines. The fields can be combined,
+// for example CorrelationEpoch | CorrelationRound
+type CorrelationField byte
+
+const (
+	// CorrelationShard selects the shard field
+	CorrelationShard CorrelationField = 1 << iota
+	// CorrelationEpoch selects the epoch field
+	CorrelationEpoch
+	// CorrelationRound selects the round field
+	CorrelationRound
+	// CorrelationSubRound selects the sub-round field
+	CorrelationSubRound
+)
+
+// defaultMaxBufferedKeys is the maximum number of keys having their own state when none is provided, so the buffered
+// log lines are discarded when the key changes
+const defaultMaxBufferedKeys = 1
+
+// ArgsFingersCrossedObserver is the argument used to create a new FingersCrossedObserver. A zero MaxBufferedKeys
+// means the default value of 1
+type ArgsFingersCrossedObserver struct {
+	Writer           io.Writer
+	Formatter        Formatter
+	BufferBelowLevel LogLevel
+	TriggerLevel     LogLevel
+	KeyFields        CorrelationField
+	MaxBufferedLines int
+	MaxBufferedKeys  int
+	BufferTimeout    time.Duration
+}
+
+// FingersCrossedObserver wraps a writer + formatter pair and holds back the detailed log lines until something goes
+// wrong. The log lines below the buffering level are buffered, grouped by the correlation key made of the selected
+// correlation fields (such as the round), the other ones being written as they are. When a log line of at least the
+// trigger level arrives, the buffered log lines of its key are written before it, the following log lines of the key
+// being buffered again. When the buffer of a key is full, its oldest buffered log line is discarded.
+// By default only the current key is buffered, so a log line of another key discards the buffered log lines of the
+// previous key. A MaxBufferedKeys above 1 gives each key its own buffer, so the interleaved keys (such as the log lines
+// of two shards) do not discard each other's log lines. When a new key would exceed the maximum number of keys, the
+// state of the least recently used key is discarded.
+// The buffered log lines of a key expire when the timeout passed between the first buffered log line and the current
+// one, while the state of a key is discarded when the timeout passed since its last log line. A zero timeout disables
+// the expiry.
+// The FingersCrossedObserver should be added with RegisterLogLineObserver.
+type FingersCrossedObserver struct {
+	mut              sync.Mutex
+	writer           io.Writer
+	formatter        Formatter
+	bufferBelowLevel LogLevel
+	triggerLevel     LogLevel
+	keyFields        CorrelationField
+	maxBufferedLines int
+	maxBufferedKeys  int
+	bufferTimeout    time.Duration
+	keys             map[proto.LogCorrelationMessage]*fingersCrossedKey
+	lastSweep        int64
+}
+
+// fingersCrossedKey holds the state of a correlation key
+type fingersCrossedKey struct {
+	buffered      []*LogLineWrapper
+	lastTimestamp int64
+}
+
+// NewFingersCrossedObserver creates a new FingersCrossedObserver around the provided writer + formatter pair
+func NewFingersCrossedObserver(args ArgsFingersCrossedObserver) (*FingersCrossedObserver, error) {
+	if args.Writer == nil {
+		return nil, ErrNilWriter
+	}
+	if check.IfNil(args.Formatter) {
+		return nil, ErrNilFormatter
+	}
+	if args.TriggerLevel < args.BufferBelowLevel {
+		return nil, ErrInvalidTriggerLevel
+	}
+	if args.MaxBufferedLines < 1 {
+		return nil, ErrInvalidBufferSize
+	}
+	if args.MaxBufferedKeys < 0 {
+		return nil, ErrInvalidMaxBufferedKeys
+	}
+	if args.BufferTimeout < 0 {
+		return nil, ErrInvalidBufferTimeout
+	}
+
+	maxBufferedKeys := args.MaxBufferedKeys
+	if maxBufferedKeys == 0 {
+		maxBufferedKeys = defaultMaxBufferedKeys
+	}
+
+	return &FingersCrossedObserver{
+		writer:           args.Writer,
+		formatter:        args.Formatter,
+		bufferBelowLevel: args.BufferBelowLevel,
+		triggerLevel:     args.TriggerLevel,
+		keyFields:        args.KeyFields,
+		maxBufferedLines: args.MaxBufferedLines,
+		maxBufferedKeys:  maxBufferedKeys,
+		bufferTimeout:    args.BufferTimeout,
+		keys:             make(map[proto.LogCorrelationMessage]*fingersCrossedKey),
+	}, nil
+}
+
+// OutputLogLine writes the formatted log line, preceded by the buffered log lines of its key if it triggers their
+// output. The log lines to be buffered are only retained
+func (fco *FingersCrossedObserver) OutputLogLine(line LogLineHandler) error {
+	if check.IfNil(line) {
+		return nil
+	}
+
+	fco.mut.Lock()
+	defer fco.mut.Unlock()
+
+	timestamp := line.GetTimestamp()
+	fco.discardIdleKeys(timestamp)
+	state := fco.getKey(fco.correlationKey(line.GetCorrelation()))
+	fco.discardExpired(state, timestamp)
+	state.lastTimestamp = timestamp
+
+	var buff []byte
+	level := LogLevel(line.GetLogLevel())
+	switch {
+	case level >= fco.triggerLevel:
+		buff = append(fco.bufferedOutput(state), formatLogLine(fco.formatter, line)...)
+	case level >= fco.bufferBelowLevel:
+		buff = formatLogLine(fco.formatter, line)
+	default:
+		fco.buffer(state, CloneLogLine(line))
+		return nil
+	}
+	if len(buff) == 0 {
+		return nil
+	}
+
+	_, err := fco.writer.Write(buff)
+
+	return err
+}
+
+// NumBufferedLines returns the number of the currently buffered log lines, for all the keys
+func (fco *FingersCrossedObserver) NumBufferedLines() int {
+	fco.mut.Lock()
+	defer fco.mut.Unlock()
+
+	numLines := 0
+	for _, state := range fco.keys {
+		numLines += len(state.buffered)
+	}
+
+	return numLines
+}
+
+// NumKeys returns the number of the keys currently having their own state
+func (fco *FingersCrossedObserver) NumKeys() int {
+	fco.mut.Lock()
+	defer fco.mut.Unlock()
+
+	return len(fco.keys)
+}
+
+func (fco *FingersCrossedObserver) correlationKey(correlation proto.LogCorrelationMessage) proto.LogCorrelationMessage {
+	key := proto.LogCorrelationMessage{}
+	if fco.keyFields&CorrelationShard != 0 {
+		key.Shard = correlation.Shard
+	}
+	if fco.keyFields&CorrelationEpoch != 0 {
+		key.Epoch = correlation.Epoch
+	}
+	if fco.keyFields&CorrelationRound != 0 {
+		key.Round = correlation.Round
+	}
+	if fco.keyFields&CorrelationSubRound != 0 {
+		key.SubRound = correlation.SubRound
+	}
+
+	return key
+}
+
+// getKey returns the state of the provided key, creating it if needed. The least recently used key is discarded if
+// the new key would exceed the maximum number of keys
+func (fco *FingersCrossedObserver) getKey(key proto.LogCorrelationMessage) *fingersCrossedKey {
+	state, found := fco.keys[key]
+	if found {
+		return state
+	}
+
+	if len(fco.keys) >= fco.maxBufferedKeys {
+		fco.discardLeastRecentlyUsedKey()
+	}
+	state = &fingersCrossedKey{}
+	fco.keys[key] = state
+
+	return state
+}
+
+func (fco *FingersCrossedObserver) discardLeastRecentlyUsedKey() {
+	var oldestKey proto.LogCorrelationMessage
+	var oldestState *fingersCrossedKey
+	for key, state := range fco.keys {
+		if oldestState == nil || state.lastTimestamp < oldestState.lastTimestamp {
+			oldestKey = key
+			oldestState = state
+		}
+	}
+
+	delete(fco.keys, oldestKey)
+}
+
+// discardIdleKeys discards the state of the keys having no log line since the timeout passed. The keys are checked
+// at most once per timeout interval
+func (fco *FingersCrossedObserver) discardIdleKeys(timestamp int64) {
+	if fco.bufferTimeout == 0 || time.Duration(timestamp-fco.lastSweep) <= fco.bufferTimeout {
+		return
+	}
+	fco.lastSweep = timestamp
+
+	for key, state := range fco.keys {
+		if time.Duration(timestamp-state.lastTimestamp) > fco.bufferTimeout {
+			delete(fco.keys, key)
+		}
+	}
+}
+
+func (fco *FingersCrossedObserver) discardExpired(state *fingersCrossedKey, timestamp int64) {
+	if fco.bufferTimeout == 0 || len(state.buffered) == 0 {
+		return
+	}
+
+	if time.Duration(timestamp-state.buffered[0].Timestamp) > fco.bufferTimeout {
+		state.buffered = nil
+	}
+}
+
+func (fco *FingersCrossedObserver) buffer(state *fingersCrossedKey, line *LogLineWrapper) {
+	if len(state.buffered) == fco.maxBufferedLines {
+		state.buffered[0] = nil
+		state.buffered = state.buffered[1:]
+	}
+
+	state.buffered = append(state.buffered, line)
+}
+
+func (fco *FingersCrossedObserver) bufferedOutput(state *fingersCrossedKey) []byte {
+	var buff []byte
+	for _, line := range state.buffered {
+		buff = append(buff, fco.formatter.Output(line)...)
+	}
+	state.buffered = nil
+
+	return buff
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (fco *FingersCrossedObserver) IsInterfaceNil() bool {
+	return fco == nil
+}
@@ -0,0 +1,287 @@
+package logger_test
+
+import (
+	"bytes"
+	"io"
+	"testing"
+	"time"
+
+	logger "github.com/Dharitri-org/me-core-logger-go"
+	"github.com/Dharitri-org/me-core-logger-go/mock"
+	"github.com/Dharitri-org/me-core-logger-go/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var fingersCrossedTestTime = time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+
+func createFingersCrossedArgs(t *testing.T, writer io.Writer) logger.ArgsFingersCrossedObserver {
+	return logger.ArgsFingersCrossedObserver{
+		Writer:           writer,
+		Formatter:        createPatternFormatter(t, "%level %msg\n"),
+		BufferBelowLevel: logger.LogInfo,
+		TriggerLevel:     logger.LogError,
+		KeyFields:        logger.CorrelationEpoch | logger.CorrelationRound,
+		MaxBufferedLines: 100,
+	}
+}
+
+func logFingersCrossedTestLine(los logger.LogOutputHandler, level logger.LogLevel, round int64, elapsed time.Duration, message string) {
+	los.Output(&logger.LogLine{
+		Message:     message,
+		LogLevel:    level,
+		Correlation: proto.LogCorrelationMessage{Shard: "0", Epoch: 3, Round: round},
+		Timestamp:   fingersCrossedTestTime.Add(elapsed),
+	})
+}
+
+func TestNewFingersCrossedObserver_InvalidArgsShouldErr(t *testing.T) {
+	t.Parallel()
+
+	args := createFingersCrossedArgs(t, nil)
+	fingersCrossed, err := logger.NewFingersCrossedObserver(args)
+	assert.Nil(t, fingersCrossed)
+	assert.Equal(t, logger.ErrNilWriter, err)
+
+	args.Writer = &bytes.Buffer{}
+	args.Formatter = nil
+	fingersCrossed, err = logger.NewFingersCrossedObserver(args)
+	assert.Nil(t, fingersCrossed)
+	assert.Equal(t, logger.ErrNilFormatter, err)
+
+	args.Formatter = &mock.FormatterStub{}
+	args.TriggerLevel = logger.LogDebug
+	fingersCrossed, err = logger.NewFingersCrossedObserver(args)
+	assert.Nil(t, fingersCrossed)
+	assert.Equal(t, logger.ErrInvalidTriggerLevel, err)
+
+	args.TriggerLevel = logger.LogError
+	args.MaxBufferedLines = 0
+	fingersCrossed, err = logger.NewFingersCrossedObserver(args)
+	assert.Nil(t, fingersCrossed)
+	assert.Equal(t, logger.ErrInvalidBufferSize, err)
+
+	args.MaxBufferedLines = 1
+	args.MaxBufferedKeys = -1
+	fingersCrossed, err = logger.NewFingersCrossedObserver(args)
+	assert.Nil(t, fingersCrossed)
+	assert.Equal(t, logger.ErrInvalidMaxBufferedKeys, err)
+
+	args.MaxBufferedKeys = 0
+	args.BufferTimeout = -time.Second
+	fingersCrossed, err = logger.NewFingersCrossedObserver(args)
+	assert.Nil(t, fingersCrossed)
+	assert.Equal(t, logger.ErrInvalidBufferTimeout, err)
+}
+
+func TestFingersCrossedObserver_TriggerShouldWriteTheBufferedLines(t *testing.T) {
+	t.Parallel()
+
+	writer := &syncBuffer{}
+	fingersCrossed, err := logger.NewFingersCrossedObserver(createFingersCrossedArgs(t, writer))
+	require.Nil(t, err)
+	los := createLineObserverTestOutput(t, fingersCrossed)
+
+	logFingersCrossedTestLine(los, logger.LogTrace, 1, 0, "requesting header")
+	logFingersCrossedTestLine(los, logger.LogDebug, 1, 0, "header received")
+	logFingersCrossedTestLine(los, logger.LogInfo, 1, 0, "round started")
+	assert.Equal(t, []string{"INFO round started"}, splitLines(writer.String()))
+
+	logFingersCrossedTestLine(los, logger.LogError, 1, 0, "header invalid")
+	expected := []string{
+		"INFO round started",
+		"TRACE requesting header",
+		"DEBUG header received",
+		"ERROR header invalid",
+	}
+	assert.Equal(t, expected, splitLines(writer.String()))
+	assert.Equal(t, 0, fingersCrossed.NumBufferedLines())
+}
+
+func TestFingersCrossedObserver_LinesAfterTheTriggerShouldBeBufferedAgain(t *testing.T) {
+	t.Parallel()
+
+	writer := &syncBuffer{}
+	fingersCrossed, err := logger.NewFingersCrossedObserver(createFingersCrossedArgs(t, writer))
+	require.Nil(t, err)
+	los := createLineObserverTestOutput(t, fingersCrossed)
+
+	logFingersCrossedTestLine(los, logger.LogDebug, 1, 0, "header received")
+	logFingersCrossedTestLine(los, logger.LogError, 1, 0, "header invalid")
+	logFingersCrossedTestLine(los, logger.LogDebug, 1, 0, "requesting header again")
+	logFingersCrossedTestLine(los, logger.LogTrace, 1, 0, "header received again")
+	expected := []string{
+		"DEBUG header received",
+		"ERROR header invalid",
+	}
+	assert.Equal(t, expected, splitLines(writer.String()))
+	assert.Equal(t, 2, fingersCrossed.NumBufferedLines())
+
+	logFingersCrossedTestLine(los, logger.LogError, 1, 0, "header invalid again")
+	expected = append(expected, "DEBUG requesting header again", "TRACE header received again", "ERROR header invalid again")
+	assert.Equal(t, expected, splitLines(writer.String()))
+}
+
+func TestFingersCrossedObserver_KeyChangeShouldDiscardTheBufferedLines(t *testing.T) {
+	t.Parallel()
+
+	writer := &syncBuffer{}
+	fingersCrossed, err := logger.NewFingersCrossedObserver(createFingersCrossedArgs(t, writer))
+	require.Nil(t, err)
+	los := createLineObserverTestOutput(t, fingersCrossed)
+
+	logFingersCrossedTestLine(los, logger.LogDebug, 1, 0, "round 1 details")
+	logFingersCrossedTestLine(los, logger.LogDebug, 2, 0, "round 2 details")
+	assert.Equal(t, 1, fingersCrossed.NumKeys())
+	assert.Equal(t, 1, fingersCrossed.NumBufferedLines())
+
+	logFingersCrossedTestLine(los, logger.LogError, 1, 0, "round 1 error")
+	assert.Equal(t, []string{"ERROR round 1 error"}, splitLines(writer.String()))
+	assert.Equal(t, 0, fingersCrossed.NumBufferedLines())
+}
+
+func TestFingersCrossedObserver_InterleavedKeysShouldKeepTheirBufferedLines(t *testing.T) {
+	t.Parallel()
+
+	writer := &syncBuffer{}
+	args := createFingersCrossedArgs(t, writer)
+	args.MaxBufferedKeys = 2
+	fingersCrossed, err := logger.NewFingersCrossedObserver(args)
+	require.Nil(t, err)
+	los := createLineObserverTestOutput(t, fingersCrossed)
+
+	logFingersCrossedTestLine(los, logger.LogDebug, 1, 0, "round 1 details")
+	logFingersCrossedTestLine(los, logger.LogDebug, 2, 0, "round 2 details")
+	logFingersCrossedTestLine(los, logger.LogWarning, 1, 0, "round 1 warning")
+	logFingersCrossedTestLine(los, logger.LogError, 2, 0, "round 2 error")
+	logFingersCrossedTestLine(los, logger.LogDebug, 1, 0, "round 1 more details")
+	assert.Equal(t, 2, fingersCrossed.NumBufferedLines())
+	logFingersCrossedTestLine(los, logger.LogError, 1, 0, "round 1 error")
+
+	expected := []string{
+		"WARN round 1 warning",
+		"DEBUG round 2 details",
+		"ERROR round 2 error",
+		"DEBUG round 1 details",
+		"DEBUG round 1 more details",
+		"ERROR round 1 error",
+	}
+	assert.Equal(t, expected, splitLines(writer.String()))
+	assert.Equal(t, 0, fingersCrossed.NumBufferedLines())
+}
+
+func TestFingersCrossedObserver_TooManyKeysShouldDiscardTheLeastRecentlyUsedOne(t *testing.T) {
+	t.Parallel()
+
+	writer := &syncBuffer{}
+	args := createFingersCrossedArgs(t, writer)
+	args.MaxBufferedKeys = 2
+	fingersCrossed, err := logger.NewFingersCrossedObserver(args)
+	require.Nil(t, err)
+	los := createLineObserverTestOutput(t, fingersCrossed)
+
+	logFingersCrossedTestLine(los, logger.LogDebug, 1, 0, "round 1 details")
+	logFingersCrossedTestLine(los, logger.LogDebug, 2, time.Millisecond, "round 2 details")
+	logFingersCrossedTestLine(los, logger.LogDebug, 1, 2*time.Millisecond, "round 1 more details")
+	logFingersCrossedTestLine(los, logger.LogDebug, 3, 3*time.Millisecond, "round 3 details")
+	assert.Equal(t, 2, fingersCrossed.NumKeys())
+	assert.Equal(t, 3, fingersCrossed.NumBufferedLines())
+
+	logFingersCrossedTestLine(los, logger.LogError, 1, 4*time.Millisecond, "round 1 error")
+	logFingersCrossedTestLine(los, logger.LogError, 2, 5*time.Millisecond, "round 2 error")
+
+	expected := []string{
+		"DEBUG round 1 details",
+		"DEBUG round 1 more details",
+		"ERROR round 1 error",
+		"ERROR round 2 error",
+	}
+	assert.Equal(t, expected, splitLines(writer.String()))
+	assert.Equal(t, 2, fingersCrossed.NumKeys())
+}
+
+func TestFingersCrossedObserver_IdleKeysShouldBeDiscarded(t *testing.T) {
+	t.Parallel()
+
+	writer := &syncBuffer{}
+	args := createFingersCrossedArgs(t, writer)
+	args.MaxBufferedKeys = 3
+	args.BufferTimeout = time.Second
+	fingersCrossed, err := logger.NewFingersCrossedObserver(args)
+	require.Nil(t, err)
+	los := createLineObserverTestOutput(t, fingersCrossed)
+
+	logFingersCrossedTestLine(los, logger.LogDebug, 1, 0, "round 1 details")
+	logFingersCrossedTestLine(los, logger.LogDebug, 2, 0, "round 2 details")
+	logFingersCrossedTestLine(los, logger.LogDebug, 3, 900*time.Millisecond, "round 3 details")
+	assert.Equal(t, 3, fingersCrossed.NumKeys())
+
+	logFingersCrossedTestLine(los, logger.LogDebug, 3, 1800*time.Millisecond, "round 3 more details")
+	assert.Equal(t, 1, fingersCrossed.NumKeys())
+	assert.Equal(t, 2, fingersCrossed.NumBufferedLines())
+
+	logFingersCrossedTestLine(los, logger.LogError, 1, 1900*time.Millisecond, "round 1 error")
+	assert.Equal(t, []string{"ERROR round 1 error"}, splitLines(writer.String()))
+}
+
+func TestFingersCrossedObserver_ExpiredBufferShouldBeDiscarded(t *testing.T) {
+	t.Parallel()
+
+	writer := &syncBuffer{}
+	args := createFingersCrossedArgs(t, writer)
+	args.BufferTimeout = time.Second
+	fingersCrossed, err := logger.NewFingersCrossedObserver(args)
+	require.Nil(t, err)
+	los := createLineObserverTestOutput(t, fingersCrossed)
+
+	logFingersCrossedTestLine(los, logger.LogDebug, 1, 0, "expired")
+	logFingersCrossedTestLine(los, logger.LogDebug, 1, 2*time.Second, "buffered")
+	logFingersCrossedTestLine(los, logger.LogError, 1, 2500*time.Millisecond, "error")
+
+	assert.Equal(t, []string{"DEBUG buffered", "ERROR error"}, splitLines(writer.String()))
+}
+
+func TestFingersCrossedObserver_FullBufferShouldDiscardTheOldestLines(t *testing.T) {
+	t.Parallel()
+
+	writer := &syncBuffer{}
+	args := createFingersCrossedArgs(t, writer)
+	args.MaxBufferedLines = 2
+	args.KeyFields = 0
+	fingersCrossed, err := logger.NewFingersCrossedObserver(args)
+	require.Nil(t, err)
+	los := createLineObserverTestOutput(t, fingersCrossed)
+
+	logFingersCrossedTestLine(los, logger.LogTrace, 1, 0, "line 1")
+	logFingersCrossedTestLine(los, logger.LogTrace, 2, 0, "line 2")
+	logFingersCrossedTestLine(los, logger.LogTrace, 3, 0, "line 3")
+	logFingersCrossedTestLine(los, logger.LogFatal, 4, 0, "fatal")
+
+	assert.Equal(t, []string{"TRACE line 2", "TRACE line 3", "FATAL fatal"}, splitLines(writer.String()))
+}
+
+func TestFingersCrossedObserver_CustomFormatterCanRetainTheLogLines(t *testing.T) {
+	t.Parallel()
+
+	lines := make([]logger.LogLineHandler, 0)
+	args := createFingersCrossedArgs(t, &bytes.Buffer{})
+	args.Formatter = &mock.FormatterStub{
+		OutputCalled: func(line logger.LogLineHandler) []byte {
+			lines = append(lines, line)
+			return nil
+		},
+	}
+	fingersCrossed, err := logger.NewFingersCrossedObserver(args)
+	require.Nil(t, err)
+	log := logger.NewLogger("sync", logger.LogTrace, createLineObserverTestOutput(t, fingersCrossed))
+
+	log.Info("first", "a", 1)
+	log.Error("second", "b", 2)
+	log.Info("third", "c", 3)
+
+	require.Equal(t, 3, len(lines))
+	assert.Equal(t, "first", lines[0].GetMessage())
+	assert.Equal(t, []string{"a", "1"}, lines[0].GetArgs())
+	assert.Equal(t, "second", lines[1].GetMessage())
+	assert.Equal(t, []string{"b", "2"}, lines[1].GetArgs())
+}
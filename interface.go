@@ -1,6 +1,7 @@
 package logger
 
 import (
+	"context"
 	"io"
 
 	"github.com/Dharitri-org/me-core-logger-go/proto"
@@ -21,6 +22,55 @@ type Logger interface {
 	IsInterfaceNil() bool
 }
 
+// ExitLogger is an optional extension of the Logger interface, implemented by the loggers of this package, able to
+// output a log line and then panic or exit the process
+type ExitLogger interface {
+	Panic(message string, args ...interface{})
+	Fatal(message string, args ...interface{})
+	PanicCtx(ctx context.Context, message string, args ...interface{})
+	FatalCtx(ctx context.Context, message string, args ...interface{})
+}
+
+// ContextLogger is an optional extension of the Logger interface, implemented by the loggers of this package,
+// able to output log lines using the correlation elements held by a context
+type ContextLogger interface {
+	TraceCtx(ctx context.Context, message string, args ...interface{})
+	DebugCtx(ctx context.Context, message string, args ...interface{})
+	InfoCtx(ctx context.Context, message string, args ...interface{})
+	WarnCtx(ctx context.Context, message string, args ...interface{})
+	ErrorCtx(ctx context.Context, message string, args ...interface{})
+	LogCtx(ctx context.Context, logLevel LogLevel, message string, args ...interface{})
+}
+
+// FieldsLogger is an optional extension of the Logger interface, implemented by the loggers of this package,
+// able to output log lines holding typed fields
+type FieldsLogger interface {
+	TraceFields(message string, fields ...Field)
+	DebugFields(message string, fields ...Field)
+	InfoFields(message string, fields ...Field)
+	WarnFields(message string, fields ...Field)
+	ErrorFields(message string, fields ...Field)
+	LogFields(logLevel LogLevel, message string, fields ...Field)
+}
+
+// LevelEnabler is an optional extension of the Logger interface, implemented by the loggers of this package, able
+// to tell if a log level is enabled, so the arguments that are expensive to compute can be skipped
+type LevelEnabler interface {
+	IsEnabled(logLevel LogLevel) bool
+}
+
+// BindingLogger is an optional extension of the Logger interface, implemented by the loggers of this package,
+// able to derive loggers that add bound key/value arguments to their log lines
+type BindingLogger interface {
+	With(args ...interface{}) Logger
+}
+
+// LogValuer defines an argument whose value is resolved only when the log line is output, after the level
+// filtering. It can be used for the arguments that are expensive to compute
+type LogValuer interface {
+	LogValue() interface{}
+}
+
 // LogLineHandler defines the get methods for a log line struct used by the formatter interface
 type LogLineHandler interface {
 	GetLoggerName() string
@@ -32,12 +82,45 @@ type LogLineHandler interface {
 	IsInterfaceNil() bool
 }
 
+// TypedArgsHandler is an optional extension of the LogLineHandler interface, implemented by the log lines of this
+// package. GetArgs returns the arguments converted to their display form while GetTypedArgs returns the same
+// arguments in their native types (it can be empty for the log lines produced by older versions)
+type TypedArgsHandler interface {
+	GetTypedArgs() []proto.LogArgument
+}
+
+// CallerHandler is an optional extension of the LogLineHandler interface, implemented by the log lines of this
+// package. GetCaller returns an empty caller location if the caller capture mode was disabled
+type CallerHandler interface {
+	GetCaller() proto.LogCallerMessage
+}
+
+// StackTraceHandler is an optional extension of the LogLineHandler interface, implemented by the log lines of this
+// package. GetStackTrace returns an empty string if no stack trace was attached
+type StackTraceHandler interface {
+	GetStackTrace() string
+}
+
 // Formatter describes what a log formatter should be able to do
 type Formatter interface {
 	Output(line LogLineHandler) []byte
 	IsInterfaceNil() bool
 }
 
+// BufferedFormatter is a Formatter able to append its output to a provided buffer, so the observers can reuse
+// their buffers between the log lines
+type BufferedFormatter interface {
+	Formatter
+	AppendOutput(buff []byte, line LogLineHandler) []byte
+}
+
+// LogLineObserver defines a component that receives the log lines themselves instead of their formatted form, such
+// as the observers wrapping a writer + formatter pair. The returned error is counted as a failed write
+type LogLineObserver interface {
+	OutputLogLine(line LogLineHandler) error
+	IsInterfaceNil() bool
+}
+
 // LogOutputHandler defines the properties of a subject-observer component
 // able to output log lines
 type LogOutputHandler interface {
@@ -48,6 +131,37 @@ type LogOutputHandler interface {
 	IsInterfaceNil() bool
 }
 
+// ObserverOptionsHandler is an optional extension of the LogOutputHandler interface, implemented by the log output
+// subjects of this package, able to filter the log lines reaching each observer
+type ObserverOptionsHandler interface {
+	AddObserverWithOptions(w io.Writer, format Formatter, options ObserverOptions) error
+	SetObserverOptions(w io.Writer, options ObserverOptions) error
+}
+
+// ObserverRegistry is an optional extension of the LogOutputHandler interface, implemented by the log output
+// subjects of this package, able to identify the observers so they can be listed, replaced and removed by their IDs
+type ObserverRegistry interface {
+	RegisterObserver(w io.Writer, format Formatter, options ObserverOptions) (ObserverID, error)
+	RegisterLogLineObserver(o LogLineObserver, options ObserverOptions) (ObserverID, error)
+	ListObservers() []ObserverInfo
+	RemoveObserverByID(id ObserverID) error
+	ReplaceObserver(id ObserverID, w io.Writer, format Formatter) error
+}
+
+// ObserverHealthHandler is an optional extension of the LogOutputHandler interface, implemented by the log output
+// subjects of this package, able to handle the observers' write errors and report their health
+type ObserverHealthHandler interface {
+	SetObserverErrorPolicy(w io.Writer, policy WriteErrorPolicy) error
+	ObserversHealth() []ObserverHealth
+}
+
+// Flusher defines a component that buffers its output and is able to flush it. The observers' writers implementing
+// it are flushed by the log output subject when requested and when the observers are removed. The log output subjects
+// of this package implement it, as well as io.Closer
+type Flusher interface {
+	Flush() error
+}
+
 // Marshalizer defines the 2 basic operations: serialize (marshal) and deserialize (unmarshal)
 type Marshalizer interface {
 	Marshal(obj interface{}) ([]byte, error)
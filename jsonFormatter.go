@@ -0,0 +1,260 @@
+package logger
+
+import (
+	"math"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/Dharitri-org/me-core-logger-go/proto"
+)
+
+const (
+	jsonKeyTimestamp = "timestamp"
+	jsonKeyLevel     = "level"
+	jsonKeyLogger    = "logger"
+	jsonKeyShard     = "shard"
+	jsonKeyEpoch     = "epoch"
+	jsonKeyRound     = "round"
+	jsonKeySubRound  = "subround"
+	jsonKeyCaller    = "caller"
+	jsonKeyFunction  = "func"
+	jsonKeyStack     = "stacktrace"
+	jsonKeyMessage   = "message"
+	jsonKeyBadKey    = "!BADKEY"
+)
+
+const hexDigits = "0123456789abcdef"
+
+// maxStackJSONKeys is the number of used keys tracked without allocations while writing a log line
+const maxStackJSONKeys = 32
+
+// FieldVisibility defines if an optional field is written by a formatter
+type FieldVisibility byte
+
+const (
+	// FieldDefault writes the field according to the global toggle (IsEnabledLoggerName or IsEnabledCorrelation)
+	FieldDefault FieldVisibility = iota
+	// FieldShown always writes the field
+	FieldShown
+	// FieldHidden never writes the field
+	FieldHidden
+)
+
+func (fv FieldVisibility) isShown(globalToggle func() bool) bool {
+	switch fv {
+	case FieldShown:
+		return true
+	case FieldHidden:
+		return false
+	default:
+		return globalToggle()
+	}
+}
+
+// JSONFormatter implements formatter interface and is used to format log lines as JSON objects, one per line
+// (JSON lines), useful when the logs are consumed by indexers. A log line is written as:
+//
+//	{"timestamp":"2006-01-02T15:04:05.999999999Z","level":"INFO","logger":"main","shard":"0","epoch":1,"round":2,
+//	"subround":"(START_ROUND)","caller":"process/block.go:12","func":"process.(*block).Process",
+//	"message":"the message","key1":"value1","key2":"value2"}
+//
+// The timestamp is written in the RFC3339Nano format, in UTC. The logger name and the correlation fields follow
+// the global toggles unless overridden by the LoggerName and Correlation options. The caller and func fields are
+// written only for the log lines having a caller location (see ToggleCaller) and the stacktrace field only for the
+// log lines having a stack trace (see SetStackTracePattern).
+// The argument values are written in their native JSON types, when the typed arguments are available: the
+// integers and the finite floating point numbers as numbers, the booleans as true or false, the nil values as null
+// and the timestamps as RFC3339Nano strings, in UTC. All the other values (including the byte slices, the durations
+// and the arguments of the log lines without typed arguments) are written as their display strings.
+// The arguments are written under the argument names and the following rules apply:
+//   - an argument name that is already used (by one of the fixed fields or by a previous argument) gets the
+//     "_2", "_3" ... suffix, so no value is lost (e.g. "message", "a", "a" become "message_2", "a", "a_2")
+//   - on an odd number of arguments, the last argument is written under the "!BADKEY" name
+type JSONFormatter struct {
+	LoggerName  FieldVisibility
+	Correlation FieldVisibility
+}
+
+// Output converts the provided LogLineHandler into a slice of bytes ready for output
+func (jf *JSONFormatter) Output(line LogLineHandler) []byte {
+	if line == nil {
+		return nil
+	}
+
+	return jf.AppendOutput(make([]byte, 0, 256), line)
+}
+
+// AppendOutput appends the formatted LogLineHandler to the provided buffer and returns the extended buffer
+func (jf *JSONFormatter) AppendOutput(buff []byte, line LogLineHandler) []byte {
+	if line == nil {
+		return buff
+	}
+
+	var keysStorage [maxStackJSONKeys]string
+	usedKeys := keysStorage[:0]
+	buff = append(buff, '{')
+
+	buff, usedKeys = appendJSONKey(buff, usedKeys, jsonKeyTimestamp)
+	buff = appendJSONTimestamp(buff, line.GetTimestamp())
+	buff, usedKeys = appendJSONStringField(buff, usedKeys, jsonKeyLevel, strings.TrimSpace(LogLevel(line.GetLogLevel()).String()))
+	if jf.LoggerName.isShown(IsEnabledLoggerName) {
+		buff, usedKeys = appendJSONStringField(buff, usedKeys, jsonKeyLogger, line.GetLoggerName())
+	}
+	if jf.Correlation.isShown(IsEnabledCorrelation) {
+		correlation := line.GetCorrelation()
+		buff, usedKeys = appendJSONStringField(buff, usedKeys, jsonKeyShard, correlation.GetShard())
+		buff, usedKeys = appendJSONKey(buff, usedKeys, jsonKeyEpoch)
+		buff = strconv.AppendUint(buff, uint64(correlation.GetEpoch()), 10)
+		buff, usedKeys = appendJSONKey(buff, usedKeys, jsonKeyRound)
+		buff = strconv.AppendInt(buff, correlation.GetRound(), 10)
+		buff, usedKeys = appendJSONStringField(buff, usedKeys, jsonKeySubRound, correlation.GetSubRound())
+	}
+	caller := getCaller(line)
+	if hasCaller(caller) {
+		buff, usedKeys = appendJSONKey(buff, usedKeys, jsonKeyCaller)
+		buff = append(buff, '"')
+		buff = appendJSONStringContent(buff, callerFile(caller))
+		buff = appendCallerLine(buff, caller)
+		buff = append(buff, '"')
+		buff, usedKeys = appendJSONStringField(buff, usedKeys, jsonKeyFunction, callerFunction(caller))
+	}
+	stackTrace := getStackTrace(line)
+	if len(stackTrace) > 0 {
+		buff, usedKeys = appendJSONStringField(buff, usedKeys, jsonKeyStack, stackTrace)
+	}
+	buff, usedKeys = appendJSONStringField(buff, usedKeys, jsonKeyMessage, line.GetMessage())
+
+	args := line.GetArgs()
+	typedArgs := getTypedArgs(line)
+	if len(typedArgs) != len(args) {
+		typedArgs = nil
+	}
+	for index := 1; index < len(args); index += 2 {
+		buff, usedKeys = appendJSONKey(buff, usedKeys, args[index-1])
+		buff = appendJSONArgValue(buff, args, typedArgs, index)
+	}
+	if len(args)%2 == 1 {
+		buff, usedKeys = appendJSONKey(buff, usedKeys, jsonKeyBadKey)
+		buff = appendJSONArgValue(buff, args, typedArgs, len(args)-1)
+	}
+
+	buff = append(buff, '}', '\n')
+
+	return buff
+}
+
+// appendJSONTimestamp appends the timestamp as a quoted RFC3339Nano string, in UTC. The format does not contain
+// characters that need escaping
+func appendJSONTimestamp(buff []byte, timestamp int64) []byte {
+	buff = append(buff, '"')
+	buff = time.Unix(0, timestamp).UTC().AppendFormat(buff, time.RFC3339Nano)
+	return append(buff, '"')
+}
+
+func appendJSONArgValue(buff []byte, args []string, typedArgs []proto.LogArgument, index int) []byte {
+	if typedArgs == nil {
+		return appendJSONString(buff, args[index])
+	}
+
+	switch value := typedArgs[index].GetValue().(type) {
+	case nil:
+		return append(buff, "null"...)
+	case *proto.LogArgument_Int64Value:
+		return strconv.AppendInt(buff, value.Int64Value, 10)
+	case *proto.LogArgument_Uint64Value:
+		return strconv.AppendUint(buff, value.Uint64Value, 10)
+	case *proto.LogArgument_DoubleValue:
+		if math.IsNaN(value.DoubleValue) || math.IsInf(value.DoubleValue, 0) {
+			return appendJSONString(buff, args[index])
+		}
+		return strconv.AppendFloat(buff, value.DoubleValue, 'g', -1, 64)
+	case *proto.LogArgument_BoolValue:
+		return strconv.AppendBool(buff, value.BoolValue)
+	case *proto.LogArgument_TimestampValue:
+		return appendJSONTimestamp(buff, value.TimestampValue)
+	default:
+		return appendJSONString(buff, args[index])
+	}
+}
+
+func appendJSONStringField(buff []byte, usedKeys []string, key string, value string) ([]byte, []string) {
+	buff, usedKeys = appendJSONKey(buff, usedKeys, key)
+	return appendJSONString(buff, value), usedKeys
+}
+
+func appendJSONKey(buff []byte, usedKeys []string, key string) ([]byte, []string) {
+	uniqueKey := key
+	for suffix := 2; isJSONKeyUsed(usedKeys, uniqueKey); suffix++ {
+		uniqueKey = key + "_" + strconv.Itoa(suffix)
+	}
+	usedKeys = append(usedKeys, uniqueKey)
+
+	if len(usedKeys) > 1 {
+		buff = append(buff, ',')
+	}
+	buff = appendJSONString(buff, uniqueKey)
+
+	return append(buff, ':'), usedKeys
+}
+
+// isJSONKeyUsed searches linearly the used keys, the log lines having only a few keys
+func isJSONKeyUsed(usedKeys []string, key string) bool {
+	for _, usedKey := range usedKeys {
+		if usedKey == key {
+			return true
+		}
+	}
+
+	return false
+}
+
+// appendJSONString appends the provided string as a quoted JSON string. The invalid UTF-8 sequences are replaced
+// by the Unicode replacement character
+func appendJSONString(buff []byte, str string) []byte {
+	buff = append(buff, '"')
+	buff = appendJSONStringContent(buff, str)
+
+	return append(buff, '"')
+}
+
+// appendJSONStringContent appends the escaped content of a JSON string, without the quotes
+func appendJSONStringContent(buff []byte, str string) []byte {
+	for i := 0; i < len(str); {
+		c := str[i]
+		if c >= utf8.RuneSelf {
+			r, size := utf8.DecodeRuneInString(str[i:])
+			if r == utf8.RuneError && size == 1 {
+				buff = append(buff, `\ufffd`...)
+			} else {
+				buff = append(buff, str[i:i+size]...)
+			}
+			i += size
+			continue
+		}
+
+		switch {
+		case c == '"' || c == '\\':
+			buff = append(buff, '\\', c)
+		case c == '\n':
+			buff = append(buff, '\\', 'n')
+		case c == '\r':
+			buff = append(buff, '\\', 'r')
+		case c == '\t':
+			buff = append(buff, '\\', 't')
+		case c < ASCIISpace:
+			buff = append(buff, '\\', 'u', '0', '0', hexDigits[c>>4], hexDigits[c&0xF])
+		default:
+			buff = append(buff, c)
+		}
+		i++
+	}
+
+	return buff
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (jf *JSONFormatter) IsInterfaceNil() bool {
+	return jf == nil
+}
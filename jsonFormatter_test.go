@@ -0,0 +1,182 @@
+package logger_test
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+	"time"
+
+	logger "github.com/Dharitri-org/me-core-logger-go"
+	"github.com/Dharitri-org/me-core-logger-go/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const jsonTestMessage = "message with \"quotes\"\nand a new line"
+
+// createFormatterTestLogLine returns a log line having the same logger name, correlation and timestamp for all
+// the formatters tests
+func createFormatterTestLogLine(message string, level logger.LogLevel, args ...string) *logger.LogLineWrapper {
+	return &logger.LogLineWrapper{
+		LogLineMessage: proto.LogLineMessage{
+			LoggerName: "process/sync",
+			Correlation: proto.LogCorrelationMessage{
+				Shard:    "metachain",
+				Epoch:    3,
+				Round:    42,
+				SubRound: "(START_ROUND)",
+			},
+			Message:   message,
+			LogLevel:  int32(level),
+			Args:      args,
+			Timestamp: time.Date(2023, 1, 2, 3, 4, 5, 123456789, time.UTC).UnixNano(),
+		},
+	}
+}
+
+func unmarshalJSONLine(t *testing.T, buff []byte) map[string]interface{} {
+	require.Equal(t, byte('\n'), buff[len(buff)-1])
+
+	fields := make(map[string]interface{})
+	err := json.Unmarshal(buff, &fields)
+	require.Nil(t, err)
+
+	return fields
+}
+
+func TestJSONFormatter_OutputNilLineShouldReturnNil(t *testing.T) {
+	t.Parallel()
+
+	jf := &logger.JSONFormatter{}
+
+	assert.False(t, jf.IsInterfaceNil())
+	assert.Nil(t, jf.Output(nil))
+}
+
+func TestJSONFormatter_OutputShouldWriteAllFields(t *testing.T) {
+	t.Parallel()
+
+	jf := &logger.JSONFormatter{
+		LoggerName:  logger.FieldShown,
+		Correlation: logger.FieldShown,
+	}
+
+	buff := jf.Output(createFormatterTestLogLine(jsonTestMessage, logger.LogWarning, "hash", "0a0b", "<nil>", "control\x01\xff"))
+
+	fields := unmarshalJSONLine(t, buff)
+	expected := map[string]interface{}{
+		"timestamp": "2023-01-02T03:04:05.123456789Z",
+		"level":     "WARN",
+		"logger":    "process/sync",
+		"shard":     "metachain",
+		"epoch":     float64(3),
+		"round":     float64(42),
+		"subround":  "(START_ROUND)",
+		"message":   "message with \"quotes\"\nand a new line",
+		"hash":      "0a0b",
+		"<nil>":     "control\x01�",
+	}
+	assert.Equal(t, expected, fields)
+}
+
+func TestJSONFormatter_OutputHiddenFieldsShouldNotWrite(t *testing.T) {
+	t.Parallel()
+
+	jf := &logger.JSONFormatter{
+		LoggerName:  logger.FieldHidden,
+		Correlation: logger.FieldHidden,
+	}
+
+	buff := jf.Output(createFormatterTestLogLine(jsonTestMessage, logger.LogWarning))
+
+	assert.Equal(t,
+		`{"timestamp":"2023-01-02T03:04:05.123456789Z","level":"WARN","message":"message with \"quotes\"\nand a new line"}`+"\n",
+		string(buff),
+	)
+}
+
+func TestJSONFormatter_OutputDuplicateKeysAndOddArgs(t *testing.T) {
+	t.Parallel()
+
+	jf := &logger.JSONFormatter{
+		LoggerName:  logger.FieldHidden,
+		Correlation: logger.FieldHidden,
+	}
+
+	buff := jf.Output(createFormatterTestLogLine(jsonTestMessage, logger.LogWarning, "a", "1", "message", "2", "a", "3", "a_2", "4", "dangling"))
+
+	fields := unmarshalJSONLine(t, buff)
+	assert.Equal(t, "1", fields["a"])
+	assert.Equal(t, "2", fields["message_2"])
+	assert.Equal(t, "3", fields["a_2"])
+	assert.Equal(t, "4", fields["a_2_2"])
+	assert.Equal(t, "dangling", fields["!BADKEY"])
+	assert.Equal(t, "message with \"quotes\"\nand a new line", fields["message"])
+}
+
+func TestJSONFormatter_OutputShouldRenderTypedArgsNatively(t *testing.T) {
+	t.Parallel()
+
+	jf := &logger.JSONFormatter{
+		LoggerName:  logger.FieldHidden,
+		Correlation: logger.FieldHidden,
+	}
+	los := logger.NewLogOutputSubject()
+	line := los.ConvertLogLine(&logger.LogLine{
+		Message: "typed",
+		Args: []interface{}{
+			"int", -3,
+			"uint", uint64(18446744073709551615),
+			"float", 1.25,
+			"nan", math.NaN(),
+			"bool", true,
+			"nil", nil,
+			"duration", time.Millisecond,
+			"timestamp", time.Date(2023, 1, 2, 3, 4, 5, 6, time.UTC),
+			"string", "str",
+		},
+		Timestamp: time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC),
+	})
+
+	buff := jf.Output(line)
+
+	expected := `{"timestamp":"2023-01-02T03:04:05Z","level":"TRACE","message":"typed","int":-3,` +
+		`"uint":18446744073709551615,"float":1.25,"nan":"NaN","bool":true,"nil":null,"duration":"1ms",` +
+		`"timestamp_2":"2023-01-02T03:04:05.000000006Z","string":"str"}` + "\n"
+	assert.Equal(t, expected, string(buff))
+}
+
+func TestJSONFormatter_OutputShouldWriteTheCaller(t *testing.T) {
+	t.Parallel()
+
+	jf := &logger.JSONFormatter{
+		LoggerName:  logger.FieldHidden,
+		Correlation: logger.FieldHidden,
+	}
+	line := createFormatterTestLogLine(jsonTestMessage, logger.LogWarning)
+	line.Caller = proto.LogCallerMessage{
+		File:     "/go/src/process/\"block\".go",
+		Line:     12,
+		Function: "process.(*block).Process",
+	}
+
+	buff := jf.Output(line)
+
+	fields := unmarshalJSONLine(t, buff)
+	assert.Equal(t, "process/\"block\".go:12", fields["caller"])
+	assert.Equal(t, "process.(*block).Process", fields["func"])
+}
+
+func TestJSONFormatter_OutputShouldWriteTheStackTrace(t *testing.T) {
+	t.Parallel()
+
+	jf := &logger.JSONFormatter{}
+	line := createFormatterTestLogLine(jsonTestMessage, logger.LogWarning)
+
+	fields := unmarshalJSONLine(t, jf.Output(line))
+	assert.NotContains(t, fields, "stacktrace")
+
+	line.StackTrace = "\tmain.main\n\t\t/src/main.go:7\n"
+	fields = unmarshalJSONLine(t, jf.Output(line))
+	assert.Equal(t, "\tmain.main\n\t\t/src/main.go:7\n", fields["stacktrace"])
+}
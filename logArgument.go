@@ -0,0 +1,81 @@
+package logger
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/Dharitri-org/me-core-logger-go/proto"
+)
+
+// newLogArgument converts the provided argument into its typed form. The types that do not have a native
+// representation are converted to strings
+func newLogArgument(obj interface{}) proto.LogArgument {
+	arg := proto.LogArgument{}
+
+	switch obj := obj.(type) {
+	case nil:
+	case int:
+		arg.Value = &proto.LogArgument_Int64Value{Int64Value: int64(obj)}
+	case int8:
+		arg.Value = &proto.LogArgument_Int64Value{Int64Value: int64(obj)}
+	case int16:
+		arg.Value = &proto.LogArgument_Int64Value{Int64Value: int64(obj)}
+	case int32:
+		arg.Value = &proto.LogArgument_Int64Value{Int64Value: int64(obj)}
+	case int64:
+		arg.Value = &proto.LogArgument_Int64Value{Int64Value: obj}
+	case uint:
+		arg.Value = &proto.LogArgument_Uint64Value{Uint64Value: uint64(obj)}
+	case uint8:
+		arg.Value = &proto.LogArgument_Uint64Value{Uint64Value: uint64(obj)}
+	case uint16:
+		arg.Value = &proto.LogArgument_Uint64Value{Uint64Value: uint64(obj)}
+	case uint32:
+		arg.Value = &proto.LogArgument_Uint64Value{Uint64Value: uint64(obj)}
+	case uint64:
+		arg.Value = &proto.LogArgument_Uint64Value{Uint64Value: obj}
+	case float32:
+		arg.Value = &proto.LogArgument_DoubleValue{DoubleValue: float64(obj)}
+	case float64:
+		arg.Value = &proto.LogArgument_DoubleValue{DoubleValue: obj}
+	case bool:
+		arg.Value = &proto.LogArgument_BoolValue{BoolValue: obj}
+	case []byte:
+		arg.Value = &proto.LogArgument_BytesValue{BytesValue: append([]byte(nil), obj...)}
+	case string:
+		arg.Value = &proto.LogArgument_StringValue{StringValue: obj}
+	case time.Duration:
+		arg.Value = &proto.LogArgument_DurationValue{DurationValue: int64(obj)}
+	case time.Time:
+		arg.Value = &proto.LogArgument_TimestampValue{TimestampValue: obj.UnixNano()}
+	default:
+		arg.Value = &proto.LogArgument_StringValue{StringValue: fmt.Sprintf("%v", obj)}
+	}
+
+	return arg
+}
+
+// LogArgumentValue returns the native value of the provided typed argument: int64, uint64, float64, bool, []byte,
+// string, time.Duration, time.Time or nil for an argument without a value
+func LogArgumentValue(arg proto.LogArgument) interface{} {
+	switch value := arg.GetValue().(type) {
+	case *proto.LogArgument_Int64Value:
+		return value.Int64Value
+	case *proto.LogArgument_Uint64Value:
+		return value.Uint64Value
+	case *proto.LogArgument_DoubleValue:
+		return value.DoubleValue
+	case *proto.LogArgument_BoolValue:
+		return value.BoolValue
+	case *proto.LogArgument_BytesValue:
+		return value.BytesValue
+	case *proto.LogArgument_StringValue:
+		return value.StringValue
+	case *proto.LogArgument_DurationValue:
+		return time.Duration(value.DurationValue)
+	case *proto.LogArgument_TimestampValue:
+		return time.Unix(0, value.TimestampValue)
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,70 @@
+package logger
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Dharitri-org/me-core-logger-go/proto"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewLogArgument_ShouldKeepTheNativeTypes(t *testing.T) {
+	t.Parallel()
+
+	timestamp := time.Unix(0, 1600000000123456789)
+	testData := []struct {
+		arg      interface{}
+		expected interface{}
+	}{
+		{nil, nil},
+		{int(-1), int64(-1)},
+		{int8(-2), int64(-2)},
+		{int32(-3), int64(-3)},
+		{uint(1), uint64(1)},
+		{uint8(2), uint64(2)},
+		{uint64(3), uint64(3)},
+		{float32(1.5), float64(1.5)},
+		{2.5, 2.5},
+		{true, true},
+		{[]byte("bytes"), []byte("bytes")},
+		{"string", "string"},
+		{time.Second, time.Second},
+		{timestamp, timestamp},
+		{errors.New("error"), "error"},
+		{LogInfo, "INFO "},
+	}
+
+	for _, data := range testData {
+		arg := newLogArgument(data.arg)
+		assert.Equal(t, data.expected, LogArgumentValue(arg), data)
+	}
+}
+
+func TestNewLogArgument_ShouldCopyTheBytes(t *testing.T) {
+	t.Parallel()
+
+	buff := []byte("bytes")
+	arg := newLogArgument(buff)
+	buff[0] = 'B'
+
+	assert.Equal(t, []byte("bytes"), LogArgumentValue(arg))
+}
+
+func TestConvertLogLine_ShouldFillTypedArgs(t *testing.T) {
+	t.Parallel()
+
+	los := NewLogOutputSubject()
+	line := los.convertLogLine(&LogLine{
+		Args: []interface{}{"num", 7, "flag", false},
+	})
+
+	assert.Equal(t, []string{"num", "7", "flag", "false"}, line.GetArgs())
+	expected := []proto.LogArgument{
+		{Value: &proto.LogArgument_StringValue{StringValue: "num"}},
+		{Value: &proto.LogArgument_Int64Value{Int64Value: 7}},
+		{Value: &proto.LogArgument_StringValue{StringValue: "flag"}},
+		{Value: &proto.LogArgument_BoolValue{BoolValue: false}},
+	}
+	assert.Equal(t, expected, getTypedArgs(line))
+}
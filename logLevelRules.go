@@ -0,0 +1,84 @@
+package logger
+
+import (
+	"strings"
+)
+
+const matchAllPattern = "*"
+
+// LogLevelRule holds one MATCHING_STRING:LOG_LEVEL pair as parsed from a log level pattern
+type LogLevelRule struct {
+	Pattern string
+	Level   LogLevel
+}
+
+// String returns the rule in its MATCHING_STRING:LOG_LEVEL form
+func (rule LogLevelRule) String() string {
+	return rule.Pattern + ":" + strings.TrimSpace(rule.Level.String())
+}
+
+// logLevelRuleSet keeps the rules of the last set log level pattern so they can be evaluated
+// for every logger, including the ones created after the pattern was set
+type logLevelRuleSet struct {
+	rules    []LogLevelRule
+	matchers []*loggerNameMatcher
+}
+
+func newLogLevelRuleSet(logLevels []LogLevel, patterns []string) (*logLevelRuleSet, error) {
+	rules := make([]LogLevelRule, len(logLevels))
+	matchers := make([]*loggerNameMatcher, len(logLevels))
+	for i := 0; i < len(logLevels); i++ {
+		matcher, err := newLoggerNameMatcher(patterns[i])
+		if err != nil {
+			return nil, err
+		}
+
+		rules[i] = LogLevelRule{
+			Pattern: patterns[i],
+			Level:   logLevels[i],
+		}
+		matchers[i] = matcher
+	}
+
+	return &logLevelRuleSet{
+		rules:    rules,
+		matchers: matchers,
+	}, nil
+}
+
+// logLevelFor applies, in order, all the matching rules on top of the provided initial log level
+func (rs *logLevelRuleSet) logLevelFor(loggerName string, initial LogLevel) LogLevel {
+	logLevel := initial
+	for i, rule := range rs.rules {
+		if rs.matchers[i].isMatching(loggerName) {
+			logLevel = rule.Level
+		}
+	}
+
+	return logLevel
+}
+
+// defaultLogLevel returns the log level set by the last match-all rule ("*" or "**") or the initial value
+// if there is no such rule
+func (rs *logLevelRuleSet) defaultLogLevel(initial LogLevel) LogLevel {
+	logLevel := initial
+	for i, rule := range rs.rules {
+		if rs.matchers[i].isMatchingAll() {
+			logLevel = rule.Level
+		}
+	}
+
+	return logLevel
+}
+
+// matchingRules returns, in the order of their evaluation, the rules that match the provided logger name
+func (rs *logLevelRuleSet) matchingRules(loggerName string) []LogLevelRule {
+	matching := make([]LogLevelRule, 0)
+	for i, rule := range rs.rules {
+		if rs.matchers[i].isMatching(loggerName) {
+			matching = append(matching, rule)
+		}
+	}
+
+	return matching
+}
@@ -0,0 +1,56 @@
+package logger
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLogLevelRuleSet_LogLevelFor(t *testing.T) {
+	t.Parallel()
+
+	ruleSet, _ := newLogLevelRuleSet(
+		[]LogLevel{LogDebug, LogInfo, LogTrace, LogError},
+		[]string{"*", "1", "*", "2"},
+	)
+
+	assert.Equal(t, LogTrace, ruleSet.logLevelFor("1", LogWarning))
+	assert.Equal(t, LogError, ruleSet.logLevelFor("2", LogWarning))
+	assert.Equal(t, LogTrace, ruleSet.logLevelFor("3", LogWarning))
+}
+
+func TestLogLevelRuleSet_NoMatchingRuleShouldKeepTheInitialLevel(t *testing.T) {
+	t.Parallel()
+
+	ruleSet, _ := newLogLevelRuleSet([]LogLevel{LogDebug}, []string{"process"})
+
+	assert.Equal(t, LogWarning, ruleSet.logLevelFor("data", LogWarning))
+	assert.Equal(t, LogWarning, ruleSet.defaultLogLevel(LogWarning))
+	assert.Empty(t, ruleSet.matchingRules("data"))
+}
+
+func TestLogLevelRuleSet_DefaultLogLevel(t *testing.T) {
+	t.Parallel()
+
+	ruleSet, _ := newLogLevelRuleSet(
+		[]LogLevel{LogDebug, LogInfo, LogTrace, LogError},
+		[]string{"*", "1", "*", "2"},
+	)
+
+	assert.Equal(t, LogTrace, ruleSet.defaultLogLevel(LogWarning))
+}
+
+func TestParseLogLevelRuleSet(t *testing.T) {
+	t.Parallel()
+
+	ruleSet, err := parseLogLevelRuleSet("*:INFO,~^process/(sync|block)$:DEBUG")
+	assert.Nil(t, err)
+	assert.Equal(t, []LogLevelRule{{Pattern: "*", Level: LogInfo}, {Pattern: "~^process/(sync|block)$", Level: LogDebug}},
+		ruleSet.rules)
+	assert.Equal(t, LogDebug, ruleSet.logLevelFor("process/sync", LogWarning))
+	assert.Equal(t, LogInfo, ruleSet.logLevelFor("process/other", LogWarning))
+
+	ruleSet, err = parseLogLevelRuleSet("~^process/(sync:DEBUG")
+	assert.ErrorIs(t, err, ErrInvalidLogLevelPattern)
+	assert.Nil(t, ruleSet)
+}
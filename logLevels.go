@@ -5,7 +5,9 @@ import (
 	"strings"
 )
 
-// LogLevel defines the priority level of a log line. Trace is the lowest priority level, Error is the highest
+// LogLevel defines the priority level of a log line. Trace is the lowest priority level, Fatal is the highest.
+// The None level, placed after Error for compatibility reasons, disables all the levels up to Error: the Panic and
+// Fatal log lines are always output as they end the normal execution
 type LogLevel byte
 
 // These constants are the string representation of the package logging levels.
@@ -16,6 +18,8 @@ const (
 	LogWarning LogLevel = 3
 	LogError   LogLevel = 4
 	LogNone    LogLevel = 5
+	LogPanic   LogLevel = 6
+	LogFatal   LogLevel = 7
 )
 
 // Levels contain all defined levels as a slice for an easier iteration
@@ -26,6 +30,8 @@ var Levels = []LogLevel{
 	LogWarning,
 	LogError,
 	LogNone,
+	LogPanic,
+	LogFatal,
 }
 
 func (level LogLevel) String() string {
@@ -42,6 +48,10 @@ func (level LogLevel) String() string {
 		return "ERROR"
 	case LogNone:
 		return "NONE "
+	case LogPanic:
+		return "PANIC"
+	case LogFatal:
+		return "FATAL"
 	default:
 		return ""
 	}
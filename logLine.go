@@ -2,19 +2,82 @@
 package logger
 
 import (
+	"sync"
 	"time"
 
 	"github.com/Dharitri-org/me-core-logger-go/proto"
+	"github.com/Dharitri-org/me-core/core/check"
 )
 
-// LogLine is the structure used to hold a log line
+// maxPooledArgs is the maximum number of arguments a log line can hold in order to be put back in its pool,
+// so a rare log line with many arguments will not keep a large buffer alive
+const maxPooledArgs = 64
+
+// LogLine is the structure used to hold a log line. The Fields are output after the Args. The Caller is empty if the caller capture mode is disabled and the
+// StackTrace is empty if no stack trace was attached
 type LogLine struct {
 	LoggerName  string
 	Correlation proto.LogCorrelationMessage
 	Message     string
 	LogLevel    LogLevel
 	Args        []interface{}
+	Fields      []Field
 	Timestamp   time.Time
+	Caller      proto.LogCallerMessage
+	StackTrace  string
+}
+
+var logLinePool = sync.Pool{
+	New: func() interface{} {
+		return &LogLine{}
+	},
+}
+
+var logLineWrapperPool = sync.Pool{
+	New: func() interface{} {
+		return &LogLineWrapper{}
+	},
+}
+
+func acquireLogLine() *LogLine {
+	return logLinePool.Get().(*LogLine)
+}
+
+func releaseLogLine(line *LogLine) {
+	if cap(line.Args) > maxPooledArgs || cap(line.Fields) > maxPooledArgs {
+		return
+	}
+
+	line.Args = nil
+	for i := range line.Fields {
+		line.Fields[i] = Field{}
+	}
+	line.Fields = line.Fields[:0]
+	line.Correlation = proto.LogCorrelationMessage{}
+	line.Caller = proto.LogCallerMessage{}
+	line.StackTrace = ""
+	logLinePool.Put(line)
+}
+
+func acquireLogLineWrapper() *LogLineWrapper {
+	return logLineWrapperPool.Get().(*LogLineWrapper)
+}
+
+func releaseLogLineWrapper(line LogLineHandler) {
+	wrapper, ok := line.(*LogLineWrapper)
+	if !ok || wrapper == nil {
+		return
+	}
+	if cap(wrapper.Args) > maxPooledArgs || cap(wrapper.TypedArgs) > maxPooledArgs {
+		return
+	}
+
+	for i := range wrapper.TypedArgs {
+		if holder, isBytes := wrapper.TypedArgs[i].Value.(*proto.LogArgument_BytesValue); isBytes {
+			holder.BytesValue = nil
+		}
+	}
+	logLineWrapperPool.Put(wrapper)
 }
 
 func newLogLine(loggerName string, correlation proto.LogCorrelationMessage, message string, logLevel LogLevel, args ...interface{}) *LogLine {
@@ -28,12 +91,78 @@ func newLogLine(loggerName string, correlation proto.LogCorrelationMessage, mess
 	}
 }
 
+var _ LogLineHandler = (*LogLineWrapper)(nil)
+var _ TypedArgsHandler = (*LogLineWrapper)(nil)
+var _ CallerHandler = (*LogLineWrapper)(nil)
+var _ StackTraceHandler = (*LogLineWrapper)(nil)
+
 // LogLineWrapper is a wrapper over protobuf.LogLineMessage that enables the structure to be used with
 // protobuf marshaller
 type LogLineWrapper struct {
 	proto.LogLineMessage
 }
 
+// CloneLogLine returns a deep copy of the provided log line handler. The log output subjects hand their pooled log
+// lines only to the formatters of this package, the other formatters receiving copies made by this function
+func CloneLogLine(line LogLineHandler) *LogLineWrapper {
+	if check.IfNil(line) {
+		return nil
+	}
+
+	clone := &LogLineWrapper{}
+	clone.LoggerName = line.GetLoggerName()
+	clone.Correlation = line.GetCorrelation()
+	clone.Message = line.GetMessage()
+	clone.LogLevel = line.GetLogLevel()
+	clone.Args = append([]string(nil), line.GetArgs()...)
+	clone.Timestamp = line.GetTimestamp()
+	clone.Caller = getCaller(line)
+	clone.StackTrace = getStackTrace(line)
+
+	typedArgs := getTypedArgs(line)
+	if len(typedArgs) > 0 {
+		clone.TypedArgs = make([]proto.LogArgument, len(typedArgs))
+		for i := range typedArgs {
+			clone.TypedArgs[i] = cloneLogArgument(typedArgs[i])
+		}
+	}
+
+	return clone
+}
+
+// getTypedArgs returns the typed arguments of the provided log line or nil if it is not a TypedArgsHandler
+func getTypedArgs(line LogLineHandler) []proto.LogArgument {
+	typedArgsLine, ok := line.(TypedArgsHandler)
+	if !ok {
+		return nil
+	}
+
+	return typedArgsLine.GetTypedArgs()
+}
+
+func cloneLogArgument(arg proto.LogArgument) proto.LogArgument {
+	switch value := arg.Value.(type) {
+	case *proto.LogArgument_Int64Value:
+		return proto.LogArgument{Value: &proto.LogArgument_Int64Value{Int64Value: value.Int64Value}}
+	case *proto.LogArgument_Uint64Value:
+		return proto.LogArgument{Value: &proto.LogArgument_Uint64Value{Uint64Value: value.Uint64Value}}
+	case *proto.LogArgument_DoubleValue:
+		return proto.LogArgument{Value: &proto.LogArgument_DoubleValue{DoubleValue: value.DoubleValue}}
+	case *proto.LogArgument_BoolValue:
+		return proto.LogArgument{Value: &proto.LogArgument_BoolValue{BoolValue: value.BoolValue}}
+	case *proto.LogArgument_BytesValue:
+		return proto.LogArgument{Value: &proto.LogArgument_BytesValue{BytesValue: append([]byte(nil), value.BytesValue...)}}
+	case *proto.LogArgument_StringValue:
+		return proto.LogArgument{Value: &proto.LogArgument_StringValue{StringValue: value.StringValue}}
+	case *proto.LogArgument_DurationValue:
+		return proto.LogArgument{Value: &proto.LogArgument_DurationValue{DurationValue: value.DurationValue}}
+	case *proto.LogArgument_TimestampValue:
+		return proto.LogArgument{Value: &proto.LogArgument_TimestampValue{TimestampValue: value.TimestampValue}}
+	default:
+		return proto.LogArgument{}
+	}
+}
+
 // IsInterfaceNil returns true if there is no value under the interface
 func (llw *LogLineWrapper) IsInterfaceNil() bool {
 	return llw == nil
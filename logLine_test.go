@@ -39,3 +39,27 @@ func testMarshalUnmarshal(t *testing.T, marshName string, marsh logger.Marshaliz
 
 	assert.Equal(t, &llwCopyForAssert, llwRecovered, fmt.Sprintf("for marshalizer %v", marshName))
 }
+
+func TestCloneLogLine(t *testing.T) {
+	t.Parallel()
+
+	assert.Nil(t, logger.CloneLogLine(nil))
+
+	llw := generateLogLineWrapper()
+	llw.TypedArgs = []proto.LogArgument{
+		{Value: &proto.LogArgument_BytesValue{BytesValue: []byte("bytes")}},
+		{Value: &proto.LogArgument_Int64Value{Int64Value: 5}},
+		{},
+		{Value: &proto.LogArgument_StringValue{StringValue: "value"}},
+	}
+
+	clone := logger.CloneLogLine(&llw)
+	assert.Equal(t, &llw, clone)
+
+	llw.Args[0] = "changed"
+	llw.TypedArgs[0].GetValue().(*proto.LogArgument_BytesValue).BytesValue[0] = 'B'
+	llw.TypedArgs[1].GetValue().(*proto.LogArgument_Int64Value).Int64Value = 6
+	assert.Equal(t, "arg1", clone.Args[0])
+	assert.Equal(t, []byte("bytes"), clone.TypedArgs[0].GetBytesValue())
+	assert.Equal(t, int64(5), clone.TypedArgs[1].GetInt64Value())
+}
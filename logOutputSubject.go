@@ -3,9 +3,12 @@ package logger
 import (
 	"fmt"
 	"io"
+	"reflect"
 	"sync"
+	"sync/atomic"
 	"unicode/utf8"
 
+	"github.com/Dharitri-org/me-core-logger-go/proto"
 	"github.com/Dharitri-org/me-core/core/check"
 )
 
@@ -15,36 +18,252 @@ const ASCIILineFeed = byte('\r')
 const ASCIINewLine = byte('\n')
 
 var _ LogOutputHandler = (*logOutputSubject)(nil)
+var _ ObserverOptionsHandler = (*logOutputSubject)(nil)
+var _ ObserverHealthHandler = (*logOutputSubject)(nil)
+var _ ObserverRegistry = (*logOutputSubject)(nil)
+var _ Flusher = (*logOutputSubject)(nil)
+var _ io.Closer = (*logOutputSubject)(nil)
 
 // logOutputSubject follows the observer-subject pattern by which it holds n Writer and n Formatters.
 // Each time a call to the Output method is done, it iterates through the containing formatters and writers
-// in order to output the data
+// in order to output the data. Each observer can restrict, by its options, the log lines it receives.
+// In the asynchronous mode, the iteration is done on a background go routine.
+// The observers are held in an immutable slice swapped atomically on each change, so the outputs do not take any
+// lock and never wait for the observers changes, which are serialized by mutObservers.
 type logOutputSubject struct {
-	mutObservers sync.RWMutex
-	writers      []io.Writer
-	formatters   []Formatter
+	mutObservers sync.Mutex
+	observers    atomic.Value
+	lastID       ObserverID
+	queueArgs    *ArgsAsyncOutput
+	mutAsync     sync.RWMutex
+	async        *asyncDispatcher
 }
 
 // NewLogOutputSubject returns an initialized, empty logOutputSubject with no observers
 func NewLogOutputSubject() *logOutputSubject {
-	return &logOutputSubject{
-		writers:    make([]io.Writer, 0),
-		formatters: make([]Formatter, 0),
-	}
+	los := &logOutputSubject{}
+	los.storeObservers(make([]*observer, 0))
+
+	return los
 }
 
-// Output triggers calls to all containing formatters and writers in order to output provided log line
-func (los *logOutputSubject) Output(line *LogLine) {
-	los.mutObservers.RLock()
+// loadObservers returns the current observers snapshot, which should not be changed
+func (los *logOutputSubject) loadObservers() []*observer {
+	return los.observers.Load().([]*observer)
+}
 
+// storeObservers replaces the observers snapshot. It should be called while holding mutObservers
+func (los *logOutputSubject) storeObservers(observers []*observer) {
+	los.observers.Store(observers)
+}
+
+// Output triggers calls to all containing formatters and writers in order to output provided log line.
+// The converted log line is pooled, the formatters not belonging to this package receiving a copy of it.
+// In the asynchronous mode, the log line is converted and queued, the formatters and writers being called
+// from the background go routine
+func (los *logOutputSubject) Output(line *LogLine) {
 	convertedLine := los.convertLogLine(line)
-	for i := 0; i < len(los.writers); i++ {
-		format := los.formatters[i]
-		buff := format.Output(convertedLine)
-		_, _ = los.writers[i].Write(buff)
+
+	los.mutAsync.RLock()
+	async := los.async
+	los.mutAsync.RUnlock()
+
+	if async != nil && async.enqueue(convertedLine) {
+		return
+	}
+
+	los.writeAndRelease(convertedLine)
+}
+
+// writeAndRelease writes the converted log line on the observers and puts it back in its pool. The observers having
+// their own queues receive the log line in their queues, the log line being put back in its pool by the last one
+// writing it
+func (los *logOutputSubject) writeAndRelease(convertedLine LogLineHandler) {
+	var queued *queuedLogLine
+	for _, obs := range los.loadObservers() {
+		queue := obs.loadQueue()
+		if queue == nil || check.IfNil(convertedLine) {
+			obs.output(convertedLine)
+			continue
+		}
+
+		if queued == nil {
+			queued = acquireQueuedLogLine(convertedLine)
+		}
+		queued.retain()
+		if !queue.enqueue(queued) {
+			obs.output(convertedLine)
+			queued.release()
+		}
 	}
 
-	los.mutObservers.RUnlock()
+	if queued == nil {
+		releaseLogLineWrapper(convertedLine)
+		return
+	}
+	queued.release()
+}
+
+// EnableAsync switches the subject in the asynchronous mode: the log lines are queued in a bounded queue and
+// written on the observers by a background go routine, so the callers will not wait for slow writers.
+// When the queue is full, the provided overflow policy is applied.
+func (los *logOutputSubject) EnableAsync(args ArgsAsyncOutput) error {
+	err := checkArgsAsyncOutput(args)
+	if err != nil {
+		return err
+	}
+
+	los.mutAsync.Lock()
+	defer los.mutAsync.Unlock()
+
+	if los.async != nil {
+		return ErrAsyncOutputAlreadyEnabled
+	}
+
+	los.async = newAsyncDispatcher(args, los.writeAndRelease, nil)
+
+	return nil
+}
+
+// DisableAsync switches the subject back in the synchronous mode after writing all the queued log lines
+func (los *logOutputSubject) DisableAsync() {
+	los.mutAsync.Lock()
+	async := los.async
+	los.async = nil
+	los.mutAsync.Unlock()
+
+	if async != nil {
+		async.close()
+	}
+}
+
+// EnableObserverQueues switches the subject in the fan-out mode: each observer gets its own bounded queue, written
+// by its own go routine, so a slow observer, such as a network sink, does not delay the others. The provided
+// arguments are used by the observers that have no queue arguments set by SetObserverQueue.
+// The mode can be combined with the asynchronous mode, the subject's queue feeding the observers queues.
+func (los *logOutputSubject) EnableObserverQueues(args ArgsAsyncOutput) error {
+	err := checkArgsAsyncOutput(args)
+	if err != nil {
+		return err
+	}
+
+	los.mutObservers.Lock()
+	defer los.mutObservers.Unlock()
+
+	if los.queueArgs != nil {
+		return ErrObserverQueuesAlreadyEnabled
+	}
+
+	los.queueArgs = &args
+	for _, obs := range los.loadObservers() {
+		obs.startQueue(args)
+	}
+
+	return nil
+}
+
+// DisableObserverQueues switches the subject back from the fan-out mode after writing all the log lines queued on the
+// observers queues
+func (los *logOutputSubject) DisableObserverQueues() {
+	los.mutObservers.Lock()
+	defer los.mutObservers.Unlock()
+
+	los.queueArgs = nil
+	for _, obs := range los.loadObservers() {
+		obs.stopQueue()
+	}
+}
+
+// SetObserverQueue sets the queue arguments of the observer having the provided ID, such as its overflow policy,
+// used in the fan-out mode instead of the ones provided to EnableObserverQueues. If the fan-out mode is enabled, the
+// observer's queue is replaced, the already queued log lines being written before the new ones
+func (los *logOutputSubject) SetObserverQueue(id ObserverID, args ArgsAsyncOutput) error {
+	err := checkArgsAsyncOutput(args)
+	if err != nil {
+		return err
+	}
+
+	los.mutObservers.Lock()
+	defer los.mutObservers.Unlock()
+
+	for _, obs := range los.loadObservers() {
+		if obs.id != id {
+			continue
+		}
+
+		obs.setQueueArgs(args)
+		if los.queueArgs != nil {
+			obs.startQueue(*los.queueArgs)
+		}
+
+		return nil
+	}
+
+	return ErrObserverNotFound
+}
+
+// Flush writes all the queued log lines, in the asynchronous mode and in the fan-out mode, then flushes the writers
+// able to do so: the ones having a Flush() error method, such as the buffered writers, and the ones having a
+// Sync() error method, such as the files. All the writers are flushed, the first encountered error being returned
+func (los *logOutputSubject) Flush() error {
+	los.flushAsync()
+
+	var firstErr error
+	for _, obs := range los.loadObservers() {
+		obs.flushQueue()
+		err := obs.flush()
+		if err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+
+	return firstErr
+}
+
+// Close switches the subject back in the synchronous mode, leaving also the fan-out mode, after writing all the
+// queued log lines, then removes all the observers, writing the log lines queued on their own queues, flushing their
+// writers and closing the ones added with the CloseOnRemove option. All the observers are released, the first
+// encountered error being returned
+func (los *logOutputSubject) Close() error {
+	los.DisableAsync()
+
+	los.mutObservers.Lock()
+	observers := los.loadObservers()
+	los.storeObservers(make([]*observer, 0))
+	los.queueArgs = nil
+	los.mutObservers.Unlock()
+
+	var firstErr error
+	for _, obs := range observers {
+		err := obs.release()
+		if err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+
+	return firstErr
+}
+
+func (los *logOutputSubject) flushAsync() {
+	los.mutAsync.RLock()
+	async := los.async
+	los.mutAsync.RUnlock()
+
+	if async != nil {
+		async.flush()
+	}
+}
+
+// DroppedLines returns the number of log lines dropped by the current asynchronous mode
+func (los *logOutputSubject) DroppedLines() uint64 {
+	los.mutAsync.RLock()
+	defer los.mutAsync.RUnlock()
+
+	if los.async == nil {
+		return 0
+	}
+
+	return los.async.droppedLines()
 }
 
 func (los *logOutputSubject) convertLogLine(logLine *LogLine) LogLineHandler {
@@ -52,32 +271,70 @@ func (los *logOutputSubject) convertLogLine(logLine *LogLine) LogLineHandler {
 		return nil
 	}
 
-	line := &LogLineWrapper{}
+	numArgs := len(logLine.Args) + 2*len(logLine.Fields)
+	line := acquireLogLineWrapper()
 	line.LoggerName = logLine.LoggerName
 	line.Correlation = logLine.Correlation
 	line.Message = logLine.Message
 	line.LogLevel = int32(logLine.LogLevel)
-	line.Args = make([]string, len(logLine.Args))
+	line.Args = resizeStrings(line.Args, numArgs)
+	line.TypedArgs = resizeLogArguments(line.TypedArgs, numArgs)
 	line.Timestamp = logLine.Timestamp.UnixNano()
+	line.Caller = logLine.Caller
+	line.StackTrace = logLine.StackTrace
 
 	mutDisplayByteSlice.RLock()
 	displayHandler := displayByteSlice
 	mutDisplayByteSlice.RUnlock()
 
 	for i, obj := range logLine.Args {
-		switch obj := obj.(type) {
-		case []byte:
-			line.Args[i] = displayHandler(obj)
-		case string:
-			line.Args[i] = convertStringIfNotASCII(displayHandler, obj)
-		default:
-			line.Args[i] = fmt.Sprintf("%v", obj)
-		}
+		obj = resolveLogValue(obj)
+		line.TypedArgs[i] = newLogArgument(obj)
+		line.Args[i] = displayArgument(displayHandler, obj)
+	}
+
+	index := len(logLine.Args)
+	for i := range logLine.Fields {
+		field := logLine.Fields[i].resolved()
+
+		line.Args[index] = convertStringIfNotASCII(displayHandler, field.Key)
+		setStringLogArgument(&line.TypedArgs[index], field.Key)
+		line.Args[index+1] = field.displayValue(displayHandler)
+		field.setLogArgument(&line.TypedArgs[index+1])
+		index += 2
 	}
 
 	return line
 }
 
+func resizeStrings(values []string, length int) []string {
+	if cap(values) < length {
+		return make([]string, length)
+	}
+
+	return values[:length]
+}
+
+func resizeLogArguments(values []proto.LogArgument, length int) []proto.LogArgument {
+	if cap(values) < length {
+		return make([]proto.LogArgument, length)
+	}
+
+	return values[:length]
+}
+
+// displayArgument returns the display form of an argument of the non-typed logger methods
+func displayArgument(displayHandler func([]byte) string, obj interface{}) string {
+	switch obj := obj.(type) {
+	case []byte:
+		return displayHandler(obj)
+	case string:
+		return convertStringIfNotASCII(displayHandler, obj)
+	default:
+		return fmt.Sprintf("%v", obj)
+	}
+}
+
 func convertStringIfNotASCII(byteHandler func([]byte) string, data string) string {
 	if isASCII(data) {
 		return data
@@ -108,48 +365,291 @@ func isASCII(data string) bool {
 
 // AddObserver adds a writer + formatter (called here observer) to the containing observer-like lists
 func (los *logOutputSubject) AddObserver(w io.Writer, format Formatter) error {
+	return los.AddObserverWithOptions(w, format, ObserverOptions{})
+}
+
+// AddObserverWithOptions adds a writer + formatter (called here observer) that will receive only the log lines
+// allowed by the provided options
+func (los *logOutputSubject) AddObserverWithOptions(w io.Writer, format Formatter, options ObserverOptions) error {
+	_, err := los.RegisterObserver(w, format, options)
+
+	return err
+}
+
+// RegisterObserver adds a writer + formatter (called here observer) that will receive only the log lines allowed
+// by the provided options, returning the ID by which the observer can be removed or replaced
+func (los *logOutputSubject) RegisterObserver(w io.Writer, format Formatter, options ObserverOptions) (ObserverID, error) {
+	obs, err := newObserver(w, format, options)
+	if err != nil {
+		return 0, err
+	}
+
+	return los.addObserver(obs), nil
+}
+
+// RegisterLogLineObserver adds a new observer handing the log lines to the provided LogLineObserver, which formats and
+// writes them itself, instead of to a writer + formatter pair. The options are applied as for RegisterObserver.
+// When removed, the LogLineObserver is flushed if it is a Flusher and closed if it is an io.Closer and the
+// CloseOnRemove option was set
+func (los *logOutputSubject) RegisterLogLineObserver(o LogLineObserver, options ObserverOptions) (ObserverID, error) {
+	obs, err := newLineObserver(o, options)
+	if err != nil {
+		return 0, err
+	}
+
+	return los.addObserver(obs), nil
+}
+
+// addObserver assigns a new ID to the provided observer and appends it to the observers
+func (los *logOutputSubject) addObserver(obs *observer) ObserverID {
+	los.mutObservers.Lock()
+	defer los.mutObservers.Unlock()
+
+	los.lastID++
+	obs.id = los.lastID
+	if los.queueArgs != nil {
+		obs.startQueue(*los.queueArgs)
+	}
+	observers := los.loadObservers()
+	newObservers := make([]*observer, 0, len(observers)+1)
+	newObservers = append(newObservers, observers...)
+	los.storeObservers(append(newObservers, obs))
+
+	return obs.id
+}
+
+// ListObservers returns the description of all the observers, in their adding order
+func (los *logOutputSubject) ListObservers() []ObserverInfo {
+	observers := los.loadObservers()
+	infoList := make([]ObserverInfo, 0, len(observers))
+	for _, obs := range observers {
+		infoList = append(infoList, obs.getInfo())
+	}
+
+	return infoList
+}
+
+// findObserver returns the first observer holding the provided writer. The writers having a not comparable type, such
+// as the structs holding slices, are never found, as the comparison would panic: they should be referred by the
+// observer IDs. It should be called while holding mutObservers
+func (los *logOutputSubject) findObserver(w io.Writer) (*observer, error) {
 	if w == nil {
-		return ErrNilWriter
+		return nil, ErrNilWriter
+	}
+	if !reflect.TypeOf(w).Comparable() {
+		return nil, ErrWriterNotFound
 	}
-	if check.IfNil(format) {
-		return ErrNilFormatter
+
+	for _, obs := range los.loadObservers() {
+		if obs.writer == w {
+			return obs, nil
+		}
 	}
 
+	return nil, ErrWriterNotFound
+}
+
+// SetObserverOptions changes the options of the observer based on the writer provided. The comparison is done
+// on pointers. If the provided writer is not contained, the function will return an error.
+func (los *logOutputSubject) SetObserverOptions(w io.Writer, options ObserverOptions) error {
 	los.mutObservers.Lock()
-	los.writers = append(los.writers, w)
-	los.formatters = append(los.formatters, format)
-	los.mutObservers.Unlock()
+	defer los.mutObservers.Unlock()
+
+	obs, err := los.findObserver(w)
+	if err != nil {
+		return err
+	}
+
+	return obs.setOptions(options)
+}
+
+// SetObserverErrorPolicy sets the write error policy of the observer based on the writer provided. The comparison is
+// done on pointers. Setting a policy resets the consecutive failures counter and enables back the observer if it was
+// disabled by its previous policy. If the provided writer is not contained, the function will return an error.
+func (los *logOutputSubject) SetObserverErrorPolicy(w io.Writer, policy WriteErrorPolicy) error {
+	los.mutObservers.Lock()
+	defer los.mutObservers.Unlock()
+
+	obs, err := los.findObserver(w)
+	if err != nil {
+		return err
+	}
+
+	obs.setPolicy(policy)
 
 	return nil
 }
 
+// ObserversHealth returns the write statistics of all the observers, in their adding order
+func (los *logOutputSubject) ObserversHealth() []ObserverHealth {
+	observers := los.loadObservers()
+	healthList := make([]ObserverHealth, 0, len(observers))
+	for _, obs := range observers {
+		healthList = append(healthList, obs.getHealth())
+	}
+
+	return healthList
+}
+
+// setNamedObserversOptions changes the options of all the observers having the name from the provided options.
+// The not found names are ignored.
+func (los *logOutputSubject) setNamedObserversOptions(optionsList []ObserverOptions) error {
+	los.mutObservers.Lock()
+	defer los.mutObservers.Unlock()
+
+	observers := los.loadObservers()
+	for _, options := range optionsList {
+		for _, obs := range observers {
+			if len(options.Name) == 0 || obs.getOptions().Name != options.Name {
+				continue
+			}
+
+			err := obs.setOptions(options)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// getNamedObserversOptions returns the options of all the named observers
+func (los *logOutputSubject) getNamedObserversOptions() []ObserverOptions {
+	observers := los.loadObservers()
+	optionsList := make([]ObserverOptions, 0, len(observers))
+	for _, obs := range observers {
+		options := obs.getOptions()
+		if len(options.Name) > 0 {
+			optionsList = append(optionsList, options)
+		}
+	}
+
+	return optionsList
+}
+
 // RemoveObserver will remove the observer based on the writer provided. The comparison is done on pointers.
 // If the provided writer is not contained, the function will return an error.
+// The removal is done in order: the queued log lines are written (in the asynchronous mode), the observer stops
+// receiving log lines, its in-flight writes started before the removal are waited for, then its writer is flushed
+// and, if the observer was added with the CloseOnRemove option, closed. The flush or close error is returned, the
+// observer being removed anyway
 func (los *logOutputSubject) RemoveObserver(w io.Writer) error {
 	if w == nil {
 		return ErrNilWriter
 	}
 
+	los.flushAsync()
+
 	los.mutObservers.Lock()
-	defer los.mutObservers.Unlock()
+	obs, err := los.findObserver(w)
+	if err == nil {
+		los.replaceObserver(obs.id, nil)
+	}
+	los.mutObservers.Unlock()
+	if err != nil {
+		return err
+	}
+
+	return obs.release()
+}
+
+// RemoveObserverByID removes the observer having the provided ID, in the same way as RemoveObserver
+func (los *logOutputSubject) RemoveObserverByID(id ObserverID) error {
+	los.flushAsync()
+
+	los.mutObservers.Lock()
+	obs := los.replaceObserver(id, nil)
+	los.mutObservers.Unlock()
+	if obs == nil {
+		return ErrObserverNotFound
+	}
+
+	return obs.release()
+}
 
-	for i := 0; i < len(los.writers); i++ {
-		if los.writers[i] == w {
-			los.writers = append(los.writers[0:i], los.writers[i+1:]...)
-			los.formatters = append(los.formatters[0:i], los.formatters[i+1:]...)
-			return nil
+// ReplaceObserver replaces the writer + formatter of the observer having the provided ID. The new observer keeps the
+// ID, the options and the write error policy of the replaced one and receives the log lines in its place, with no
+// log line being lost or written twice. The replaced observer is then released in the same way as RemoveObserver
+// does, so, for example, a rotated file can be replaced by the new one and closed
+func (los *logOutputSubject) ReplaceObserver(id ObserverID, w io.Writer, format Formatter) error {
+	los.flushAsync()
+
+	los.mutObservers.Lock()
+	obs, err := los.createReplacement(id, w, format)
+	var replaced *observer
+	if err == nil {
+		replaced = los.replaceObserver(id, obs)
+		if los.queueArgs != nil {
+			obs.startQueue(*los.queueArgs)
 		}
 	}
+	los.mutObservers.Unlock()
+	if err != nil {
+		return err
+	}
 
-	return ErrWriterNotFound
+	return replaced.release()
 }
 
-// ClearObservers clears the observers lists
+// createReplacement creates an observer copying the settings of the one having the provided ID. It should be called
+// while holding mutObservers
+func (los *logOutputSubject) createReplacement(id ObserverID, w io.Writer, format Formatter) (*observer, error) {
+	for _, existing := range los.loadObservers() {
+		if existing.id != id {
+			continue
+		}
+
+		config := existing.loadConfig()
+		obs, err := newObserver(w, format, config.options)
+		if err != nil {
+			return nil, err
+		}
+		obs.id = id
+		obs.setPolicy(config.policy)
+		if config.queueArgs != nil {
+			obs.setQueueArgs(*config.queueArgs)
+		}
+
+		return obs, nil
+	}
+
+	return nil, ErrObserverNotFound
+}
+
+// replaceObserver stores a snapshot in which the observer having the provided ID is replaced by the provided one or,
+// if nil, removed. The replaced observer is returned, nil meaning it was not found.
+// It should be called while holding mutObservers
+func (los *logOutputSubject) replaceObserver(id ObserverID, replacement *observer) *observer {
+	observers := los.loadObservers()
+	for i, obs := range observers {
+		if obs.id != id {
+			continue
+		}
+
+		newObservers := make([]*observer, 0, len(observers))
+		newObservers = append(newObservers, observers[:i]...)
+		if replacement != nil {
+			newObservers = append(newObservers, replacement)
+		}
+		los.storeObservers(append(newObservers, observers[i+1:]...))
+
+		return obs
+	}
+
+	return nil
+}
+
+// ClearObservers clears the observers lists. In the fan-out mode, the log lines queued on the observers queues
+// are written before
 func (los *logOutputSubject) ClearObservers() {
 	los.mutObservers.Lock()
 
-	los.writers = make([]io.Writer, 0)
-	los.formatters = make([]Formatter, 0)
+	observers := los.loadObservers()
+	los.storeObservers(make([]*observer, 0))
+	for _, obs := range observers {
+		obs.stopQueue()
+	}
 
 	los.mutObservers.Unlock()
 }
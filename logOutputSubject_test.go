@@ -3,6 +3,7 @@ package logger_test
 import (
 	"bytes"
 	"errors"
+	"io"
 	"sync"
 	"sync/atomic"
 	"testing"
@@ -353,3 +354,813 @@ func BenchmarkIsASCII(b *testing.B) {
 		}
 	})
 }
+
+// ------- observer options
+
+func TestLogOutputSubject_AddObserverWithOptionsInvalidPatternShouldError(t *testing.T) {
+	t.Parallel()
+
+	los := logger.NewLogOutputSubject()
+
+	err := los.AddObserverWithOptions(&mock.WriterStub{}, &mock.FormatterStub{}, logger.ObserverOptions{LoggerNamePattern: "!!a"})
+
+	assert.NotNil(t, err)
+	obs, _ := los.Observers()
+	assert.Equal(t, 0, len(obs))
+}
+
+func TestLogOutputSubject_OutputShouldFilterByObserverOptions(t *testing.T) {
+	t.Parallel()
+
+	los := logger.NewLogOutputSubject()
+
+	allLines := make([]string, 0)
+	warnLines := make([]string, 0)
+	processLines := make([]string, 0)
+	formatter := &mock.FormatterStub{
+		OutputCalled: func(line logger.LogLineHandler) []byte {
+			return []byte(line.GetMessage())
+		},
+	}
+	_ = los.AddObserver(&mock.WriterStub{
+		WriteCalled: func(p []byte) (n int, err error) {
+			allLines = append(allLines, string(p))
+			return len(p), nil
+		},
+	}, formatter)
+	_ = los.AddObserverWithOptions(&mock.WriterStub{
+		WriteCalled: func(p []byte) (n int, err error) {
+			warnLines = append(warnLines, string(p))
+			return len(p), nil
+		},
+	}, formatter, logger.ObserverOptions{MinLevel: logger.LogWarning})
+	_ = los.AddObserverWithOptions(&mock.WriterStub{
+		WriteCalled: func(p []byte) (n int, err error) {
+			processLines = append(processLines, string(p))
+			return len(p), nil
+		},
+	}, formatter, logger.ObserverOptions{LoggerNamePattern: "process/**;!process/sync"})
+
+	los.Output(&logger.LogLine{LoggerName: "process", LogLevel: logger.LogDebug, Message: "m1"})
+	los.Output(&logger.LogLine{LoggerName: "process/sync", LogLevel: logger.LogError, Message: "m2"})
+	los.Output(&logger.LogLine{LoggerName: "main", LogLevel: logger.LogWarning, Message: "m3"})
+
+	assert.Equal(t, []string{"m1", "m2", "m3"}, allLines)
+	assert.Equal(t, []string{"m2", "m3"}, warnLines)
+	assert.Equal(t, []string{"m1"}, processLines)
+}
+
+func TestLogOutputSubject_SetObserverOptions(t *testing.T) {
+	t.Parallel()
+
+	los := logger.NewLogOutputSubject()
+
+	numWrites := 0
+	w := &mock.WriterStub{
+		WriteCalled: func(p []byte) (n int, err error) {
+			numWrites++
+			return len(p), nil
+		},
+	}
+	_ = los.AddObserver(w, &mock.FormatterStub{
+		OutputCalled: func(line logger.LogLineHandler) []byte {
+			return nil
+		},
+	})
+
+	err := los.SetObserverOptions(nil, logger.ObserverOptions{})
+	assert.Equal(t, logger.ErrNilWriter, err)
+
+	err = los.SetObserverOptions(&mock.WriterStub{}, logger.ObserverOptions{})
+	assert.Equal(t, logger.ErrWriterNotFound, err)
+
+	err = los.SetObserverOptions(w, logger.ObserverOptions{LoggerNamePattern: "~("})
+	assert.NotNil(t, err)
+
+	err = los.SetObserverOptions(w, logger.ObserverOptions{MinLevel: logger.LogError})
+	assert.Nil(t, err)
+
+	los.Output(&logger.LogLine{LogLevel: logger.LogWarning})
+	assert.Equal(t, 0, numWrites)
+
+	los.Output(&logger.LogLine{LogLevel: logger.LogError})
+	assert.Equal(t, 1, numWrites)
+}
+
+type flushingWriterStub struct {
+	mock.WriterStub
+	FlushCalled func() error
+}
+
+func (fws *flushingWriterStub) Flush() error {
+	return fws.FlushCalled()
+}
+
+func TestLogOutputSubject_FlushShouldFlushAllTheWriters(t *testing.T) {
+	t.Parallel()
+
+	expectedErr := errors.New("expected error")
+	numFlushed := 0
+	los := logger.NewLogOutputSubject()
+	for _, flushErr := range []error{nil, expectedErr, errors.New("other error")} {
+		returnedErr := flushErr
+		writer := &flushingWriterStub{
+			FlushCalled: func() error {
+				numFlushed++
+				return returnedErr
+			},
+		}
+		_ = los.AddObserver(writer, &mock.FormatterStub{})
+	}
+	_ = los.AddObserver(&bytes.Buffer{}, &mock.FormatterStub{})
+
+	err := los.Flush()
+	assert.Equal(t, expectedErr, err)
+	assert.Equal(t, 3, numFlushed)
+}
+
+type closingWriterStub struct {
+	flushingWriterStub
+	CloseCalled func() error
+}
+
+func (cws *closingWriterStub) Close() error {
+	return cws.CloseCalled()
+}
+
+func createClosingWriterStub(operations *[]string, name string) *closingWriterStub {
+	return &closingWriterStub{
+		flushingWriterStub: flushingWriterStub{
+			WriterStub: mock.WriterStub{
+				WriteCalled: func(p []byte) (n int, err error) {
+					*operations = append(*operations, name+" write")
+					return len(p), nil
+				},
+			},
+			FlushCalled: func() error {
+				*operations = append(*operations, name+" flush")
+				return nil
+			},
+		},
+		CloseCalled: func() error {
+			*operations = append(*operations, name+" close")
+			return nil
+		},
+	}
+}
+
+func TestLogOutputSubject_RemoveObserverShouldFlushAndCloseTheWriter(t *testing.T) {
+	t.Parallel()
+
+	operations := make([]string, 0)
+	closing := createClosingWriterStub(&operations, "closing")
+	notClosing := createClosingWriterStub(&operations, "not closing")
+	formatter := &mock.FormatterStub{
+		OutputCalled: func(line logger.LogLineHandler) []byte {
+			return []byte(line.GetMessage())
+		},
+	}
+	los := logger.NewLogOutputSubject()
+	_ = los.AddObserverWithOptions(closing, formatter, logger.ObserverOptions{CloseOnRemove: true})
+	_ = los.AddObserver(notClosing, formatter)
+	err := los.EnableAsync(logger.ArgsAsyncOutput{QueueSize: 10})
+	require.Nil(t, err)
+	defer los.DisableAsync()
+
+	err = los.SetObserverOptions(closing, logger.ObserverOptions{Name: "closing"})
+	require.Nil(t, err)
+
+	los.Output(&logger.LogLine{Message: "message"})
+	err = los.RemoveObserver(closing)
+	assert.Nil(t, err)
+	err = los.RemoveObserver(notClosing)
+	assert.Nil(t, err)
+	err = los.RemoveObserver(notClosing)
+	assert.Equal(t, logger.ErrWriterNotFound, err)
+
+	expected := []string{"closing write", "not closing write", "closing flush", "closing close", "not closing flush"}
+	assert.Equal(t, expected, operations)
+}
+
+func TestLogOutputSubject_CloseShouldReleaseAllTheObservers(t *testing.T) {
+	t.Parallel()
+
+	operations := make([]string, 0)
+	closing := createClosingWriterStub(&operations, "closing")
+	notClosing := createClosingWriterStub(&operations, "not closing")
+	los := logger.NewLogOutputSubject()
+	_ = los.AddObserver(notClosing, &mock.FormatterStub{})
+	_ = los.AddObserverWithOptions(closing, &mock.FormatterStub{}, logger.ObserverOptions{CloseOnRemove: true})
+
+	err := los.Close()
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"not closing flush", "closing flush", "closing close"}, operations)
+	assert.Equal(t, logger.ErrWriterNotFound, los.RemoveObserver(closing))
+	los.Output(&logger.LogLine{Message: "message"})
+	assert.Equal(t, 3, len(operations))
+}
+
+func createFailingWriterStub(numWrites *int, numFailures int) *mock.WriterStub {
+	return &mock.WriterStub{
+		WriteCalled: func(p []byte) (n int, err error) {
+			*numWrites++
+			if *numWrites <= numFailures {
+				return 0, errors.New("write error")
+			}
+			return len(p), nil
+		},
+	}
+}
+
+func createMessageFormatterStub() *mock.FormatterStub {
+	return &mock.FormatterStub{
+		OutputCalled: func(line logger.LogLineHandler) []byte {
+			return []byte(line.GetMessage())
+		},
+	}
+}
+
+func TestLogOutputSubject_SetObserverErrorPolicyWriterNotFoundShouldError(t *testing.T) {
+	t.Parallel()
+
+	los := logger.NewLogOutputSubject()
+	err := los.SetObserverErrorPolicy(nil, logger.WriteErrorPolicy{})
+	assert.Equal(t, logger.ErrNilWriter, err)
+
+	err = los.SetObserverErrorPolicy(&bytes.Buffer{}, logger.WriteErrorPolicy{})
+	assert.Equal(t, logger.ErrWriterNotFound, err)
+}
+
+func TestLogOutputSubject_OutputShouldCountTheWriteErrors(t *testing.T) {
+	t.Parallel()
+
+	numWrites := 0
+	writer := createFailingWriterStub(&numWrites, 2)
+	los := logger.NewLogOutputSubject()
+	_ = los.AddObserverWithOptions(writer, createMessageFormatterStub(), logger.ObserverOptions{Name: "failing"})
+
+	for i := 0; i < 3; i++ {
+		los.Output(&logger.LogLine{Message: "message"})
+	}
+
+	healthList := los.ObserversHealth()
+	require.Equal(t, 1, len(healthList))
+	health := healthList[0]
+	assert.Equal(t, "failing", health.Name)
+	assert.Equal(t, "*mock.WriterStub", health.WriterType)
+	assert.Equal(t, uint64(3), health.NumWrites)
+	assert.Equal(t, uint64(2), health.NumFailures)
+	assert.Equal(t, uint64(0), health.ConsecutiveFailures)
+	assert.Equal(t, "write error", health.LastError)
+	assert.False(t, health.LastErrorTime.IsZero())
+	assert.False(t, health.IsDisabled)
+}
+
+func TestLogOutputSubject_OutputShouldRetryTheFailedWrites(t *testing.T) {
+	t.Parallel()
+
+	numWrites := 0
+	writer := createFailingWriterStub(&numWrites, 2)
+	los := logger.NewLogOutputSubject()
+	_ = los.AddObserver(writer, createMessageFormatterStub())
+	err := los.SetObserverErrorPolicy(writer, logger.WriteErrorPolicy{
+		MaxRetries:   2,
+		RetryBackoff: 5 * time.Millisecond,
+	})
+	require.Nil(t, err)
+
+	start := time.Now()
+	los.Output(&logger.LogLine{Message: "message"})
+	assert.True(t, time.Since(start) >= 15*time.Millisecond)
+	assert.Equal(t, 3, numWrites)
+
+	health := los.ObserversHealth()[0]
+	assert.Equal(t, uint64(1), health.NumWrites)
+	assert.Equal(t, uint64(0), health.NumFailures)
+}
+
+func TestLogOutputSubject_OutputShouldRetryOnlyTheBytesNotWritten(t *testing.T) {
+	t.Parallel()
+
+	output := &bytes.Buffer{}
+	numWrites := 0
+	writer := &mock.WriterStub{
+		WriteCalled: func(p []byte) (n int, err error) {
+			numWrites++
+			if numWrites == 1 {
+				return output.Write(p[:3])
+			}
+			return output.Write(p)
+		},
+	}
+	los := logger.NewLogOutputSubject()
+	_ = los.AddObserver(writer, createMessageFormatterStub())
+	err := los.SetObserverErrorPolicy(writer, logger.WriteErrorPolicy{MaxRetries: 1})
+	require.Nil(t, err)
+
+	los.Output(&logger.LogLine{Message: "message"})
+
+	assert.Equal(t, 2, numWrites)
+	assert.Equal(t, "message", output.String())
+	assert.Equal(t, uint64(0), los.ObserversHealth()[0].NumFailures)
+}
+
+func TestLogOutputSubject_OutputShouldCapTheRetryBackoff(t *testing.T) {
+	t.Parallel()
+
+	numWrites := 0
+	writer := createFailingWriterStub(&numWrites, 2)
+	los := logger.NewLogOutputSubject()
+	_ = los.AddObserver(writer, createMessageFormatterStub())
+	err := los.SetObserverErrorPolicy(writer, logger.WriteErrorPolicy{
+		MaxRetries:   2,
+		RetryBackoff: time.Hour,
+	})
+	require.Nil(t, err)
+
+	start := time.Now()
+	los.Output(&logger.LogLine{Message: "message"})
+	assert.True(t, time.Since(start) < time.Second)
+	assert.Equal(t, 3, numWrites)
+}
+
+func TestLogOutputSubject_OutputShouldDisableTheObserverAndFailOver(t *testing.T) {
+	t.Parallel()
+
+	numWrites := 0
+	writer := createFailingWriterStub(&numWrites, 100)
+	fallback := &bytes.Buffer{}
+	handledErrors := make([]error, 0)
+	formatter := createMessageFormatterStub()
+	los := logger.NewLogOutputSubject()
+	_ = los.AddObserverWithOptions(writer, formatter, logger.ObserverOptions{Name: "failing"})
+	policy := logger.WriteErrorPolicy{
+		MaxConsecutiveFailures: 2,
+		FallbackWriter:         fallback,
+		ErrorHandler: func(observerName string, err error) {
+			assert.Equal(t, "failing", observerName)
+			handledErrors = append(handledErrors, err)
+		},
+	}
+	err := los.SetObserverErrorPolicy(writer, policy)
+	require.Nil(t, err)
+
+	for i := 0; i < 3; i++ {
+		los.Output(&logger.LogLine{Message: "message"})
+	}
+
+	assert.Equal(t, 2, numWrites)
+	assert.Equal(t, "messagemessage", fallback.String())
+	require.Equal(t, 2, len(handledErrors))
+	assert.False(t, errors.Is(handledErrors[0], logger.ErrObserverDisabled))
+	assert.True(t, errors.Is(handledErrors[1], logger.ErrObserverDisabled))
+	health := los.ObserversHealth()[0]
+	assert.True(t, health.IsDisabled)
+	assert.Equal(t, uint64(2), health.ConsecutiveFailures)
+	assert.Equal(t, uint64(2), health.NumFallbackWrites)
+
+	err = los.SetObserverErrorPolicy(writer, logger.WriteErrorPolicy{})
+	require.Nil(t, err)
+	los.Output(&logger.LogLine{Message: "message"})
+	assert.Equal(t, 3, numWrites)
+	health = los.ObserversHealth()[0]
+	assert.False(t, health.IsDisabled)
+	assert.Equal(t, uint64(3), health.NumFailures)
+}
+
+func TestLogOutputSubject_OutputShortWriteShouldCountAsFailure(t *testing.T) {
+	t.Parallel()
+
+	writer := &mock.WriterStub{
+		WriteCalled: func(p []byte) (n int, err error) {
+			return len(p) - 1, nil
+		},
+	}
+	formatter := createMessageFormatterStub()
+	los := logger.NewLogOutputSubject()
+	_ = los.AddObserver(writer, formatter)
+
+	los.Output(&logger.LogLine{Message: "message"})
+
+	health := los.ObserversHealth()[0]
+	assert.Equal(t, uint64(1), health.NumFailures)
+	assert.Equal(t, "short write", health.LastError)
+}
+
+func TestLogOutputSubject_OutputConcurrentWithObserversChangesShouldWork(t *testing.T) {
+	t.Parallel()
+
+	los := logger.NewLogOutputSubject()
+	stable := &discardingWriter{}
+	options := logger.ObserverOptions{Name: "stable", LoggerNamePattern: "test*"}
+	_ = los.AddObserverWithOptions(stable, &logger.PlainFormatter{}, options)
+
+	numOutputs := 100
+	numChanges := 50
+	wg := sync.WaitGroup{}
+	wg.Add(5)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < numOutputs; i++ {
+			los.Output(&logger.LogLine{LoggerName: "test", Message: "message", LogLevel: logger.LogInfo})
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for i := 0; i < numChanges; i++ {
+			writer := &discardingWriter{}
+			assert.Nil(t, los.AddObserver(writer, &logger.PlainFormatter{}))
+			assert.Nil(t, los.RemoveObserver(writer))
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for i := 0; i < numChanges; i++ {
+			options := logger.ObserverOptions{Name: "stable", LoggerNamePattern: "test*", MinLevel: logger.LogLevel(i % 3)}
+			assert.Nil(t, los.SetObserverOptions(stable, options))
+			assert.Nil(t, los.SetObserverErrorPolicy(stable, logger.WriteErrorPolicy{MaxRetries: i % 2}))
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for i := 0; i < numChanges; i++ {
+			_ = los.ObserversHealth()
+			_ = los.Flush()
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for i := 0; i < numOutputs; i++ {
+			los.Output(&logger.LogLine{LoggerName: "other", Message: "message", LogLevel: logger.LogError})
+		}
+	}()
+	wg.Wait()
+
+	assert.Equal(t, uint64(numOutputs), atomic.LoadUint64(&stable.numWrites))
+	healthList := los.ObserversHealth()
+	require.Equal(t, 1, len(healthList))
+	assert.Equal(t, "stable", healthList[0].Name)
+}
+
+func TestLogOutputSubject_ObserversChangesShouldNotWaitForTheInFlightWrites(t *testing.T) {
+	t.Parallel()
+
+	operations := make([]string, 0)
+	mutOperations := sync.Mutex{}
+	record := func(operation string) {
+		mutOperations.Lock()
+		operations = append(operations, operation)
+		mutOperations.Unlock()
+	}
+
+	chWriteStarted := make(chan struct{})
+	chUnblockWrite := make(chan struct{})
+	blocking := &closingWriterStub{
+		flushingWriterStub: flushingWriterStub{
+			WriterStub: mock.WriterStub{
+				WriteCalled: func(p []byte) (n int, err error) {
+					record("write started")
+					close(chWriteStarted)
+					<-chUnblockWrite
+					record("write ended")
+					return len(p), nil
+				},
+			},
+			FlushCalled: func() error {
+				record("flush")
+				return nil
+			},
+		},
+		CloseCalled: func() error {
+			record("close")
+			return nil
+		},
+	}
+	los := logger.NewLogOutputSubject()
+	_ = los.AddObserverWithOptions(blocking, createMessageFormatterStub(), logger.ObserverOptions{CloseOnRemove: true})
+	go los.Output(&logger.LogLine{Message: "message"})
+	<-chWriteStarted
+
+	other := &bytes.Buffer{}
+	err := los.AddObserver(other, createMessageFormatterStub())
+	require.Nil(t, err)
+	err = los.SetObserverOptions(blocking, logger.ObserverOptions{MinLevel: logger.LogError})
+	require.Nil(t, err)
+
+	chRemoved := make(chan error)
+	go func() {
+		chRemoved <- los.RemoveObserver(blocking)
+	}()
+	select {
+	case <-chRemoved:
+		require.Fail(t, "the observer was released during its in-flight write")
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	los.Output(&logger.LogLine{Message: "other message"})
+	assert.Equal(t, "other message", other.String())
+
+	close(chUnblockWrite)
+	assert.Nil(t, <-chRemoved)
+	los.Output(&logger.LogLine{Message: "ignored message"})
+
+	mutOperations.Lock()
+	defer mutOperations.Unlock()
+	assert.Equal(t, []string{"write started", "write ended", "flush", "close"}, operations)
+}
+
+type valueWriter struct {
+	lines *[]string
+	tags  []string
+}
+
+func (vw valueWriter) Write(p []byte) (int, error) {
+	*vw.lines = append(*vw.lines, string(p))
+	return len(p), nil
+}
+
+func TestLogOutputSubject_RegisterObserverShouldAllowTheSameWriterTwice(t *testing.T) {
+	t.Parallel()
+
+	writer := &bytes.Buffer{}
+	los := logger.NewLogOutputSubject()
+	firstID, err := los.RegisterObserver(writer, createMessageFormatterStub(), logger.ObserverOptions{Name: "message"})
+	require.Nil(t, err)
+	secondID, err := los.RegisterObserver(writer, &logger.PlainFormatter{}, logger.ObserverOptions{})
+	require.Nil(t, err)
+	assert.NotEqual(t, firstID, secondID)
+
+	_, err = los.RegisterObserver(nil, &logger.PlainFormatter{}, logger.ObserverOptions{})
+	assert.Equal(t, logger.ErrNilWriter, err)
+
+	los.Output(&logger.LogLine{Message: "message"})
+	infoList := los.ListObservers()
+	require.Equal(t, 2, len(infoList))
+	assert.Equal(t, firstID, infoList[0].ID)
+	assert.Equal(t, "message", infoList[0].Name)
+	assert.Equal(t, "*mock.FormatterStub", infoList[0].FormatterType)
+	assert.Equal(t, "*bytes.Buffer", infoList[0].Health.WriterType)
+	assert.Equal(t, uint64(1), infoList[0].Health.NumWrites)
+	assert.Equal(t, secondID, infoList[1].ID)
+	assert.Equal(t, "", infoList[1].Name)
+	assert.Equal(t, "*logger.PlainFormatter", infoList[1].FormatterType)
+
+	err = los.RemoveObserverByID(firstID)
+	assert.Nil(t, err)
+	err = los.RemoveObserverByID(firstID)
+	assert.Equal(t, logger.ErrObserverNotFound, err)
+
+	infoList = los.ListObservers()
+	require.Equal(t, 1, len(infoList))
+	assert.Equal(t, secondID, infoList[0].ID)
+}
+
+func TestLogOutputSubject_RegisterLogLineObserver(t *testing.T) {
+	t.Parallel()
+
+	los := logger.NewLogOutputSubject()
+	_, err := los.RegisterLogLineObserver(nil, logger.ObserverOptions{})
+	assert.Equal(t, logger.ErrNilLogLineObserver, err)
+
+	messages := make([]string, 0)
+	lines := make([]logger.LogLineHandler, 0)
+	operations := make([]string, 0)
+	expectedErr := errors.New("expected error")
+	observer := &mock.LogLineObserverStub{
+		OutputLogLineCalled: func(line logger.LogLineHandler) error {
+			messages = append(messages, line.GetMessage())
+			lines = append(lines, line)
+			if line.GetMessage() == "failing" {
+				return expectedErr
+			}
+			return nil
+		},
+		FlushCalled: func() error {
+			operations = append(operations, "flush")
+			return nil
+		},
+		CloseCalled: func() error {
+			operations = append(operations, "close")
+			return nil
+		},
+	}
+	id, err := los.RegisterLogLineObserver(observer, logger.ObserverOptions{
+		Name:          "lines",
+		MinLevel:      logger.LogInfo,
+		CloseOnRemove: true,
+	})
+	require.Nil(t, err)
+
+	los.Output(&logger.LogLine{Message: "debug", LogLevel: logger.LogDebug})
+	los.Output(&logger.LogLine{Message: "info", LogLevel: logger.LogInfo, Args: []interface{}{"a", 1}})
+	los.Output(&logger.LogLine{Message: "failing", LogLevel: logger.LogWarning})
+	assert.Equal(t, []string{"info", "failing"}, messages)
+	assert.Equal(t, []string{"a", "1"}, lines[0].GetArgs())
+
+	infoList := los.ListObservers()
+	require.Equal(t, 1, len(infoList))
+	assert.Equal(t, id, infoList[0].ID)
+	assert.Equal(t, "lines", infoList[0].Name)
+	assert.Equal(t, "*mock.LogLineObserverStub", infoList[0].FormatterType)
+	assert.Equal(t, "*mock.LogLineObserverStub", infoList[0].Health.WriterType)
+	assert.Equal(t, uint64(0), infoList[0].Health.NumWrites)
+	assert.Equal(t, uint64(1), infoList[0].Health.NumFailures)
+	assert.Equal(t, expectedErr.Error(), infoList[0].Health.LastError)
+
+	err = los.RemoveObserverByID(id)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"flush", "close"}, operations)
+	los.Output(&logger.LogLine{Message: "removed", LogLevel: logger.LogError})
+	assert.Equal(t, 2, len(messages))
+}
+
+func TestLogOutputSubject_ValueTypeWriterShouldBeRemovedByID(t *testing.T) {
+	t.Parallel()
+
+	lines := make([]string, 0)
+	writer := valueWriter{lines: &lines}
+	los := logger.NewLogOutputSubject()
+	id, err := los.RegisterObserver(writer, createMessageFormatterStub(), logger.ObserverOptions{})
+	require.Nil(t, err)
+
+	los.Output(&logger.LogLine{Message: "message"})
+	assert.Equal(t, logger.ErrWriterNotFound, los.RemoveObserver(writer))
+	assert.Equal(t, logger.ErrWriterNotFound, los.SetObserverOptions(writer, logger.ObserverOptions{}))
+
+	err = los.RemoveObserverByID(id)
+	assert.Nil(t, err)
+	los.Output(&logger.LogLine{Message: "message"})
+	assert.Equal(t, []string{"message"}, lines)
+}
+
+func TestLogOutputSubject_ReplaceObserver(t *testing.T) {
+	t.Parallel()
+
+	operations := make([]string, 0)
+	rotated := createClosingWriterStub(&operations, "rotated")
+	current := createClosingWriterStub(&operations, "current")
+	options := logger.ObserverOptions{Name: "file", MinLevel: logger.LogInfo, CloseOnRemove: true}
+	los := logger.NewLogOutputSubject()
+	id, err := los.RegisterObserver(rotated, createMessageFormatterStub(), options)
+	require.Nil(t, err)
+	err = los.ReplaceObserver(id+1, current, &logger.PlainFormatter{})
+	assert.Equal(t, logger.ErrObserverNotFound, err)
+	err = los.ReplaceObserver(id, nil, &logger.PlainFormatter{})
+	assert.Equal(t, logger.ErrNilWriter, err)
+
+	los.Output(&logger.LogLine{Message: "message", LogLevel: logger.LogInfo})
+	err = los.ReplaceObserver(id, current, createMessageFormatterStub())
+	assert.Nil(t, err)
+	los.Output(&logger.LogLine{Message: "message", LogLevel: logger.LogInfo})
+	los.Output(&logger.LogLine{Message: "message", LogLevel: logger.LogDebug})
+
+	expected := []string{"rotated write", "rotated flush", "rotated close", "current write"}
+	assert.Equal(t, expected, operations)
+	infoList := los.ListObservers()
+	require.Equal(t, 1, len(infoList))
+	assert.Equal(t, id, infoList[0].ID)
+	assert.Equal(t, "file", infoList[0].Name)
+	assert.Equal(t, uint64(1), infoList[0].Health.NumWrites)
+
+	err = los.Close()
+	assert.Nil(t, err)
+	assert.Equal(t, append(expected, "current flush", "current close"), operations)
+}
+
+type discardingWriter struct {
+	numWrites uint64
+}
+
+func (dw *discardingWriter) Write(p []byte) (int, error) {
+	atomic.AddUint64(&dw.numWrites, 1)
+	return len(p), nil
+}
+
+// rwMutexLogOutputSubject reproduces the previous design of the log output subject, in which the observers were
+// guarded by a RWMutex held during the Output calls, so adding or removing an observer blocked the logging. It is the
+// baseline of the Output benchmarks
+type rwMutexLogOutputSubject struct {
+	mutObservers sync.RWMutex
+	writers      []io.Writer
+	formatters   []logger.Formatter
+	converter    interface {
+		ConvertLogLine(logLine *logger.LogLine) logger.LogLineHandler
+	}
+}
+
+func newRWMutexLogOutputSubject() *rwMutexLogOutputSubject {
+	return &rwMutexLogOutputSubject{
+		converter: logger.NewLogOutputSubject(),
+	}
+}
+
+func (los *rwMutexLogOutputSubject) Output(line *logger.LogLine) {
+	los.mutObservers.RLock()
+	defer los.mutObservers.RUnlock()
+
+	convertedLine := los.converter.ConvertLogLine(line)
+	for i := range los.writers {
+		_, _ = los.writers[i].Write(los.formatters[i].Output(convertedLine))
+	}
+}
+
+func (los *rwMutexLogOutputSubject) AddObserver(w io.Writer, format logger.Formatter) error {
+	los.mutObservers.Lock()
+	los.writers = append(los.writers, w)
+	los.formatters = append(los.formatters, format)
+	los.mutObservers.Unlock()
+
+	return nil
+}
+
+func (los *rwMutexLogOutputSubject) RemoveObserver(w io.Writer) error {
+	los.mutObservers.Lock()
+	defer los.mutObservers.Unlock()
+
+	for i := range los.writers {
+		if los.writers[i] == w {
+			los.writers = append(los.writers[:i], los.writers[i+1:]...)
+			los.formatters = append(los.formatters[:i], los.formatters[i+1:]...)
+			return nil
+		}
+	}
+
+	return logger.ErrWriterNotFound
+}
+
+func (los *rwMutexLogOutputSubject) ClearObservers() {
+	los.mutObservers.Lock()
+	los.writers = nil
+	los.formatters = nil
+	los.mutObservers.Unlock()
+}
+
+func (los *rwMutexLogOutputSubject) IsInterfaceNil() bool {
+	return los == nil
+}
+
+func benchmarkLogOutputSubjectOutput(b *testing.B, los logger.LogOutputHandler, rotate bool) {
+	current := &discardingWriter{}
+	_ = los.AddObserver(current, &logger.PlainFormatter{})
+	_ = los.AddObserver(&discardingWriter{}, &logger.PlainFormatter{})
+
+	chStop := make(chan struct{})
+	wg := sync.WaitGroup{}
+	if rotate {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-chStop:
+					return
+				default:
+				}
+
+				next := &discardingWriter{}
+				_ = los.AddObserver(next, &logger.PlainFormatter{})
+				_ = los.RemoveObserver(current)
+				current = next
+			}
+		}()
+	}
+
+	line := &logger.LogLine{
+		LoggerName: "process/block",
+		Message:    "block processed",
+		LogLevel:   logger.LogInfo,
+		Args:       []interface{}{"nonce", 9876, "hash", "a4c7ba0d"},
+		Timestamp:  time.Now(),
+	}
+
+	b.ReportAllocs()
+	b.SetParallelism(8)
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			los.Output(line)
+		}
+	})
+	b.StopTimer()
+
+	close(chStop)
+	wg.Wait()
+}
+
+func BenchmarkLogOutputSubject_OutputConcurrent(b *testing.B) {
+	b.Run("snapshot", func(b *testing.B) {
+		benchmarkLogOutputSubjectOutput(b, logger.NewLogOutputSubject(), false)
+	})
+	b.Run("RWMutex baseline", func(b *testing.B) {
+		benchmarkLogOutputSubjectOutput(b, newRWMutexLogOutputSubject(), false)
+	})
+}
+
+func BenchmarkLogOutputSubject_OutputConcurrentDuringRotation(b *testing.B) {
+	b.Run("snapshot", func(b *testing.B) {
+		benchmarkLogOutputSubjectOutput(b, logger.NewLogOutputSubject(), true)
+	})
+	b.Run("RWMutex baseline", func(b *testing.B) {
+		benchmarkLogOutputSubjectOutput(b, newRWMutexLogOutputSubject(), true)
+	})
+}
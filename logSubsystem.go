@@ -1,6 +1,8 @@
 package logger
 
 import (
+	"context"
+	"fmt"
 	"io"
 	"os"
 	"strings"
@@ -9,9 +11,10 @@ import (
 
 var logMut = &sync.RWMutex{}
 var loggers map[string]*logger
-var defaultLogOut LogOutputHandler
+var defaultLogOut *logOutputSubject
 var defaultLogLevel = LogInfo
 var logPattern = ""
+var logLevelRules *logLevelRuleSet
 var withLoggerName bool
 
 var mutDisplayByteSlice = &sync.RWMutex{}
@@ -19,6 +22,7 @@ var displayByteSlice func(slice []byte) string
 
 func init() {
 	logPattern = "*:INFO"
+	logLevelRules, _ = newLogLevelRuleSet([]LogLevel{LogInfo}, []string{matchAllPattern})
 	loggers = make(map[string]*logger)
 	defaultLogOut = NewLogOutputSubject()
 	_ = defaultLogOut.AddObserver(os.Stdout, &ConsoleFormatter{})
@@ -26,14 +30,20 @@ func init() {
 	displayByteSlice = ToHex
 }
 
-// GetOrCreate returns a log based on the name provided, generating a new log if there is no log with provided name
+// GetOrCreate returns a log based on the name provided, generating a new log if there is no log with provided name.
+// A newly generated log will have its log level set by evaluating the rules of the last set log level pattern
+// and its stack trace level and sampling set by evaluating the rules of the last set stack trace and sampling
+// patterns.
 func GetOrCreate(name string) *logger {
 	logMut.Lock()
 	defer logMut.Unlock()
 
 	loggerFromMap, ok := loggers[name]
 	if !ok {
-		loggerFromMap = NewLogger(name, defaultLogLevel, defaultLogOut)
+		logLevel := logLevelRules.logLevelFor(name, defaultLogLevel)
+		loggerFromMap = NewLogger(name, logLevel, defaultLogOut)
+		loggerFromMap.setStackTraceLevel(stackTraceLevelFor(name))
+		loggerFromMap.setSampler(samplerFor(name))
 		loggers[name] = loggerFromMap
 	}
 
@@ -43,21 +53,29 @@ func GetOrCreate(name string) *logger {
 // SetLogLevel changes the log level of the contained loggers. The expected format is
 // "MATCHING_STRING1:LOG_LEVEL1,MATCHING_STRING2:LOG_LEVEL2".
 // If matching string is *, it will change the log levels of all contained loggers and will also set the
-// defaultLogLevelProperty. Otherwise, the log level will be modified only on those loggers that will contain the
-// matching string on any position.
-// For example, having the parameter "DEBUG|process" will set the DEBUG level on all loggers that will contain
+// defaultLogLevelProperty. Otherwise, the log level will be modified only on those loggers that are matched by
+// the matching string. A plain matching string will match the loggers that contain it on any position.
+// For example, having the parameter "process:DEBUG" will set the DEBUG level on all loggers that will contain
 // the "process" string in their name ("process/sync", "process/interceptors", "process" and so on).
+// The matching string also accepts path globs ("process/*", "process/**"), exact names ("=process"),
+// regular expressions ("~^process/(sync|block)$") and exclusions ("process/**;!process/sync"). See
+// loggerNameMatcher for the complete syntax. As the rules are separated by ',', a matching string, including a
+// regular expression, cannot contain the ',' character.
 // The rules are applied in the exact manner as they are provided, starting from left to the right part of the string
 // Example: *:INFO,p2p:ERROR,*:DEBUG,data:INFO will result in having the data package logger(s) on INFO log level
 // and all other packages on DEBUG level
+// The rules are kept until the next call so that loggers created afterwards will also be set accordingly.
+// The returned error wraps ErrInvalidLogLevelPattern along with the faulty rule, so it should be checked with
+// errors.Is instead of being compared directly.
 func SetLogLevel(logLevelAndPattern string) error {
-	logLevels, patterns, err := ParseLogLevelAndMatchingString(logLevelAndPattern)
+	ruleSet, err := parseLogLevelRuleSet(logLevelAndPattern)
 	if err != nil {
 		return err
 	}
 
 	logMut.Lock()
-	setLogLevelOnMap(loggers, &defaultLogLevel, logLevels, patterns)
+	setLogLevelOnMap(loggers, &defaultLogLevel, ruleSet)
+	logLevelRules = ruleSet
 	logPattern = logLevelAndPattern
 	logMut.Unlock()
 
@@ -87,6 +105,15 @@ func GetLoggerLogLevel(loggerName string) LogLevel {
 	return logLevel
 }
 
+// GetMatchingLogLevelRules returns, in their evaluation order, the rules of the last set log level pattern
+// that match the provided logger name. The last returned rule is the one that decided the logger's log level.
+func GetMatchingLogLevelRules(loggerName string) []LogLevelRule {
+	logMut.RLock()
+	defer logMut.RUnlock()
+
+	return logLevelRules.matchingRules(loggerName)
+}
+
 // ToggleLoggerName enables / disables logger name
 func ToggleLoggerName(enable bool) {
 	logMut.Lock()
@@ -114,67 +141,196 @@ func AddLogObserver(w io.Writer, formatter Formatter) error {
 	return defaultLogOut.AddObserver(w, formatter)
 }
 
-// RemoveLogObserver removes an exiting observer by providing the writer pointer.
+// AddLogObserverWithOptions adds a new observer (writer + formatter) that will receive only the log lines allowed
+// by the provided options. For example, an observer with the LogWarning minimum level will not receive the info log
+// lines even if the loggers' levels allow them. An observer with a name can also be referred by the profiles.
+func AddLogObserverWithOptions(w io.Writer, formatter Formatter, options ObserverOptions) error {
+	return defaultLogOut.AddObserverWithOptions(w, formatter, options)
+}
+
+// RegisterLogObserver adds a new observer in the same way as AddLogObserverWithOptions, returning the ID by which
+// the observer can be removed or replaced
+func RegisterLogObserver(w io.Writer, formatter Formatter, options ObserverOptions) (ObserverID, error) {
+	return defaultLogOut.RegisterObserver(w, formatter, options)
+}
+
+// RegisterLogLineObserver adds a new observer handing the log lines to the provided LogLineObserver, such as
+// a DedupObserver, returning the ID by which the observer can be removed or replaced
+func RegisterLogLineObserver(o LogLineObserver, options ObserverOptions) (ObserverID, error) {
+	return defaultLogOut.RegisterLogLineObserver(o, options)
+}
+
+// ListLogObservers returns the description of the observers of the default log output subject
+func ListLogObservers() []ObserverInfo {
+	return defaultLogOut.ListObservers()
+}
+
+// ReplaceLogObserver replaces the writer + formatter of an existing observer by providing its ID. The replaced
+// writer is flushed and, if the observer was added with the CloseOnRemove option, closed.
+func ReplaceLogObserver(id ObserverID, w io.Writer, formatter Formatter) error {
+	return defaultLogOut.ReplaceObserver(id, w, formatter)
+}
+
+// RemoveLogObserverByID removes an existing observer by providing its ID. The writer is flushed and, if the
+// observer was added with the CloseOnRemove option, closed.
+func RemoveLogObserverByID(id ObserverID) error {
+	return defaultLogOut.RemoveObserverByID(id)
+}
+
+// SetLogObserverOptions changes the options of an existing observer by providing the writer pointer
+func SetLogObserverOptions(w io.Writer, options ObserverOptions) error {
+	return defaultLogOut.SetObserverOptions(w, options)
+}
+
+// SetLogObserverErrorPolicy sets the write error policy of an existing observer by providing the writer pointer
+func SetLogObserverErrorPolicy(w io.Writer, policy WriteErrorPolicy) error {
+	return defaultLogOut.SetObserverErrorPolicy(w, policy)
+}
+
+// GetLogObserversHealth returns the write statistics of the observers of the default log output subject
+func GetLogObserversHealth() []ObserverHealth {
+	return defaultLogOut.ObserversHealth()
+}
+
+// RemoveLogObserver removes an exiting observer by providing the writer pointer. The writer is flushed and, if the
+// observer was added with the CloseOnRemove option, closed.
 func RemoveLogObserver(w io.Writer) error {
 	return defaultLogOut.RemoveObserver(w)
 }
 
+// EnableAsyncLogOutput switches the default log output subject in the asynchronous mode, so the log calls will
+// not wait for the observers to write the log lines
+func EnableAsyncLogOutput(args ArgsAsyncOutput) error {
+	return defaultLogOut.EnableAsync(args)
+}
+
+// DisableAsyncLogOutput switches the default log output subject back in the synchronous mode after writing all
+// the queued log lines
+func DisableAsyncLogOutput() {
+	defaultLogOut.DisableAsync()
+}
+
+// GetDroppedLogLines returns the number of log lines dropped by the asynchronous mode of the default log output subject
+func GetDroppedLogLines() uint64 {
+	return defaultLogOut.DroppedLines()
+}
+
+// EnableLogObserverQueues switches the default log output subject in the fan-out mode, in which each observer has its
+// own queue, so a slow observer, such as a network sink, does not delay the console or the log file
+func EnableLogObserverQueues(args ArgsAsyncOutput) error {
+	return defaultLogOut.EnableObserverQueues(args)
+}
+
+// DisableLogObserverQueues switches the default log output subject back from the fan-out mode after writing all the
+// log lines queued on the observers queues
+func DisableLogObserverQueues() {
+	defaultLogOut.DisableObserverQueues()
+}
+
+// SetLogObserverQueue sets the queue arguments, such as the overflow policy, of an existing observer by providing
+// its ID, used in the fan-out mode
+func SetLogObserverQueue(id ObserverID, args ArgsAsyncOutput) error {
+	return defaultLogOut.SetObserverQueue(id, args)
+}
+
+// Shutdown outputs the pending sampling summaries, then closes the default log output subject: the queued log lines
+// are written, then all the observers are removed, their writers being flushed and, if added with the CloseOnRemove
+// option, closed. It waits at most until the provided context is done, returning the context error in that case.
+// It should be called when the application stops, as the log lines output afterwards are not written anymore
+func Shutdown(ctx context.Context) error {
+	chClosed := make(chan error, 1)
+	go func() {
+		outputAllSamplingSummaries(true)
+		chClosed <- defaultLogOut.Close()
+	}()
+
+	select {
+	case err := <-chClosed:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
 // ClearLogObservers clears the observers lists
 func ClearLogObservers() {
 	defaultLogOut.ClearObservers()
 }
 
-func setLogLevelOnMap(loggers map[string]*logger, dest *LogLevel, logLevels []LogLevel, patterns []string) {
-	for i := 0; i < len(logLevels); i++ {
-		pattern := patterns[i]
-		logLevel := logLevels[i]
-		for name, log := range loggers {
-			isMatching := pattern == "*" || strings.Contains(name, pattern)
-			if isMatching {
-				log.SetLevel(logLevel)
-			}
-		}
-
-		if pattern == "*" {
-			*dest = logLevel
-		}
+func setLogLevelOnMap(loggers map[string]*logger, dest *LogLevel, ruleSet *logLevelRuleSet) {
+	for name, log := range loggers {
+		log.SetLevel(ruleSet.logLevelFor(name, log.GetLevel()))
 	}
+
+	*dest = ruleSet.defaultLogLevel(*dest)
 }
 
 // ParseLogLevelAndMatchingString can parse a string in the form "MATCHING_STRING1:LOG_LEVEL1,MATCHING_STRING2:LOG_LEVEL2" into its
-// corresponding log level and matching string. Errors if something goes wrong.
-// For example, having the parameter "DEBUG|process" will set the DEBUG level on all loggers that will contain
+// corresponding log level and matching string. Errors if something goes wrong, the error pointing to the faulty rule.
+// For example, having the parameter "process:DEBUG" will set the DEBUG level on all loggers that will contain
 // the "process" string in their name ("process/sync", "process/interceptors", "process" and so on).
 // The rules are applied in the exact manner as they are provided, starting from left to the right part of the string
 // Example: *:INFO,p2p:ERROR,*:DEBUG,data:INFO will result in having the data package logger(s) on INFO log level
 // and all other packages on DEBUG level
 func ParseLogLevelAndMatchingString(logLevelAndPatterns string) ([]LogLevel, []string, error) {
+	ruleSet, err := parseLogLevelRuleSet(logLevelAndPatterns)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	levels := make([]LogLevel, len(ruleSet.rules))
+	patterns := make([]string, len(ruleSet.rules))
+	for i, rule := range ruleSet.rules {
+		levels[i] = rule.Level
+		patterns[i] = rule.Pattern
+	}
+
+	return levels, patterns, nil
+}
+
+// parseLogLevelRuleSet parses the rules and keeps their matchers, so each matching string is compiled only once
+func parseLogLevelRuleSet(logLevelAndPatterns string) (*logLevelRuleSet, error) {
 	splitLevelPatterns := strings.Split(logLevelAndPatterns, ",")
 
-	levels := make([]LogLevel, len(splitLevelPatterns))
-	patterns := make([]string, len(splitLevelPatterns))
+	ruleSet := &logLevelRuleSet{
+		rules:    make([]LogLevelRule, len(splitLevelPatterns)),
+		matchers: make([]*loggerNameMatcher, len(splitLevelPatterns)),
+	}
 	for i, levelPattern := range splitLevelPatterns {
-		level, pattern, err := parseLevelPattern(levelPattern)
+		level, pattern, matcher, err := parseLevelPattern(levelPattern)
 		if err != nil {
-			return nil, nil, err
+			return nil, fmt.Errorf("%w: rule %d '%s': %s", ErrInvalidLogLevelPattern, i+1, levelPattern, err.Error())
 		}
 
-		levels[i] = level
-		patterns[i] = pattern
+		ruleSet.rules[i] = LogLevelRule{
+			Pattern: pattern,
+			Level:   level,
+		}
+		ruleSet.matchers[i] = matcher
 	}
 
-	return levels, patterns, nil
+	return ruleSet, nil
 }
 
-func parseLevelPattern(logLevelAndPattern string) (LogLevel, string, error) {
-	input := strings.Split(logLevelAndPattern, ":")
-	if len(input) != 2 {
-		return LogTrace, "", ErrInvalidLogLevelPattern
+func parseLevelPattern(logLevelAndPattern string) (LogLevel, string, *loggerNameMatcher, error) {
+	// the last separator is considered as the matching string might be a regular expression containing ':'
+	separatorIndex := strings.LastIndex(logLevelAndPattern, ":")
+	if separatorIndex < 0 {
+		return LogTrace, "", nil, errMissingLogLevel
 	}
 
-	logLevel, err := GetLogLevel(input[1])
+	pattern := logLevelAndPattern[:separatorIndex]
+	logLevel, err := GetLogLevel(logLevelAndPattern[separatorIndex+1:])
+	if err != nil {
+		return LogTrace, "", nil, err
+	}
+
+	matcher, err := newLoggerNameMatcher(pattern)
+	if err != nil {
+		return LogTrace, "", nil, fmt.Errorf("invalid matching string '%s': %w", pattern, err)
+	}
 
-	return logLevel, input[0], err
+	return logLevel, pattern, matcher, nil
 }
 
 // SetDisplayByteSlice sets the converter function from byte slice to string
@@ -1,16 +1,22 @@
 package logger_test
 
 import (
+	"context"
+	"errors"
+	"os"
 	"testing"
+	"time"
 
 	logger "github.com/Dharitri-org/me-core-logger-go"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestSetLogLevel_WrongStringParameterShouldErr(t *testing.T) {
 	err := logger.SetLogLevel("wrong string")
 
-	assert.Equal(t, logger.ErrInvalidLogLevelPattern, err)
+	assert.True(t, errors.Is(err, logger.ErrInvalidLogLevelPattern))
+	assert.Contains(t, err.Error(), "rule 1 'wrong string'")
 }
 
 func TestSetLogLevel_WrongLogLevelShouldErr(t *testing.T) {
@@ -84,3 +90,106 @@ func TestGetLoggerLogLevel(t *testing.T) {
 	// rollback to the default value
 	_ = logger.SetLogLevel("*:INFO")
 }
+
+func TestSetLogLevel_LoggersCreatedAfterwardsShouldHaveTheMatchingLevel(t *testing.T) {
+	err := logger.SetLogLevel("*:DEBUG,late-process:TRACE,late-process/sync:ERROR")
+	assert.Nil(t, err)
+
+	log1 := logger.GetOrCreate("late-process/interceptors")
+	log2 := logger.GetOrCreate("late-process/sync")
+	log3 := logger.GetOrCreate("late-data")
+
+	assert.Equal(t, logger.LogTrace, log1.LogLevel())
+	assert.Equal(t, logger.LogError, log2.LogLevel())
+	assert.Equal(t, logger.LogDebug, log3.LogLevel())
+
+	// rollback to the default value
+	_ = logger.SetLogLevel("*:INFO")
+}
+
+func TestGetMatchingLogLevelRules(t *testing.T) {
+	err := logger.SetLogLevel("*:DEBUG,rules-process:TRACE,rules-data:WARN,rules-process/sync:ERROR")
+	assert.Nil(t, err)
+
+	expected := []logger.LogLevelRule{
+		{Pattern: "*", Level: logger.LogDebug},
+		{Pattern: "rules-process", Level: logger.LogTrace},
+		{Pattern: "rules-process/sync", Level: logger.LogError},
+	}
+	assert.Equal(t, expected, logger.GetMatchingLogLevelRules("rules-process/sync"))
+	assert.Equal(t, "rules-process/sync:ERROR", expected[2].String())
+
+	// rollback to the default value
+	_ = logger.SetLogLevel("*:INFO")
+}
+
+func TestSetLogLevel_InvalidMatchingStringShouldErrWithTheFaultyRule(t *testing.T) {
+	err := logger.SetLogLevel("*:INFO,process;!:DEBUG")
+
+	assert.True(t, errors.Is(err, logger.ErrInvalidLogLevelPattern))
+	assert.Contains(t, err.Error(), "rule 2 'process;!:DEBUG'")
+	assert.Equal(t, "*:INFO", logger.GetLogLevelPattern())
+
+	err = logger.SetLogLevel("~process(:DEBUG")
+
+	assert.True(t, errors.Is(err, logger.ErrInvalidLogLevelPattern))
+	assert.Contains(t, err.Error(), "rule 1 '~process(:DEBUG'")
+}
+
+func TestSetLogLevel_ExtendedSyntaxShouldWork(t *testing.T) {
+	log1 := logger.GetOrCreate("syntax/process")
+	log2 := logger.GetOrCreate("syntax/process/sync")
+	log3 := logger.GetOrCreate("syntax/process/p2pAntiflood")
+	log4 := logger.GetOrCreate("syntax/p2p")
+
+	err := logger.SetLogLevel("*:INFO,syntax/process/**;!syntax/process/sync:DEBUG,=syntax/p2p:TRACE,~sync$:ERROR")
+
+	assert.Nil(t, err)
+	assert.Equal(t, logger.LogDebug, log1.LogLevel())
+	assert.Equal(t, logger.LogError, log2.LogLevel())
+	assert.Equal(t, logger.LogDebug, log3.LogLevel())
+	assert.Equal(t, logger.LogTrace, log4.LogLevel())
+
+	// rollback to the default value
+	_ = logger.SetLogLevel("*:INFO")
+}
+
+type blockingFlushWriter struct {
+	chUnblock chan struct{}
+	chFlushed chan struct{}
+}
+
+func (bfw *blockingFlushWriter) Write(p []byte) (int, error) {
+	return len(p), nil
+}
+
+func (bfw *blockingFlushWriter) Flush() error {
+	<-bfw.chUnblock
+	close(bfw.chFlushed)
+	return nil
+}
+
+func TestShutdown(t *testing.T) {
+	defer func() {
+		_ = logger.AddLogObserver(os.Stdout, &logger.ConsoleFormatter{})
+	}()
+
+	writer := &blockingFlushWriter{
+		chUnblock: make(chan struct{}),
+		chFlushed: make(chan struct{}),
+	}
+	err := logger.AddLogObserver(writer, &logger.PlainFormatter{})
+	require.Nil(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	err = logger.Shutdown(ctx)
+	assert.Equal(t, context.DeadlineExceeded, err)
+
+	close(writer.chUnblock)
+	<-writer.chFlushed
+	assert.Equal(t, logger.ErrWriterNotFound, logger.RemoveLogObserver(writer))
+
+	err = logger.Shutdown(context.Background())
+	assert.Nil(t, err)
+}
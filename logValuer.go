@@ -0,0 +1,66 @@
+package logger
+
+import "fmt"
+
+// maxLogValuerDepth is the maximum number of chained LogValuer resolutions done for an argument, so a LogValuer
+// returning itself will not block the logger
+const maxLogValuerDepth = 10
+
+// lazyValue is the LogValuer produced by Lazy
+type lazyValue func() interface{}
+
+// Lazy returns a LogValuer that calls the provided function only when the log line is output, after the level
+// filtering. A nil function produces a nil value
+func Lazy(f func() interface{}) LogValuer {
+	return lazyValue(f)
+}
+
+// LogValue returns the value produced by the wrapped function
+func (lv lazyValue) LogValue() interface{} {
+	if lv == nil {
+		return nil
+	}
+
+	return lv()
+}
+
+// resolveLogValue returns the value of the provided argument, resolving the LogValuer arguments. A panic in
+// a LogValue method is reported in the same form fmt reports the panics in the String methods
+func resolveLogValue(obj interface{}) (value interface{}) {
+	defer func() {
+		r := recover()
+		if r != nil {
+			value = fmt.Sprintf("%%!v(PANIC=LogValue method: %v)", r)
+		}
+	}()
+
+	for i := 0; i < maxLogValuerDepth; i++ {
+		valuer, ok := obj.(LogValuer)
+		if !ok {
+			return obj
+		}
+		obj = valuer.LogValue()
+	}
+
+	return obj
+}
+
+// resolveLogValues returns the arguments with the LogValuer arguments resolved. The provided slice is returned
+// unchanged if it does not contain LogValuer arguments
+func resolveLogValues(args []interface{}) []interface{} {
+	for i, obj := range args {
+		if _, ok := obj.(LogValuer); !ok {
+			continue
+		}
+
+		resolved := make([]interface{}, len(args))
+		copy(resolved, args[:i])
+		for j := i; j < len(args); j++ {
+			resolved[j] = resolveLogValue(args[j])
+		}
+
+		return resolved
+	}
+
+	return args
+}
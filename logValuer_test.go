@@ -0,0 +1,64 @@
+package logger
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type selfValuer struct{}
+
+func (sv *selfValuer) LogValue() interface{} {
+	return sv
+}
+
+func TestResolveLogValue(t *testing.T) {
+	t.Parallel()
+
+	t.Run("not a LogValuer should return the argument", func(t *testing.T) {
+		t.Parallel()
+
+		assert.Equal(t, 5, resolveLogValue(5))
+		assert.Nil(t, resolveLogValue(nil))
+	})
+	t.Run("chained LogValuers should resolve", func(t *testing.T) {
+		t.Parallel()
+
+		value := Lazy(func() interface{} {
+			return Lazy(func() interface{} {
+				return "value"
+			})
+		})
+		assert.Equal(t, "value", resolveLogValue(value))
+	})
+	t.Run("nil Lazy function should resolve to nil", func(t *testing.T) {
+		t.Parallel()
+
+		assert.Nil(t, resolveLogValue(Lazy(nil)))
+	})
+	t.Run("self returning LogValuer should stop", func(t *testing.T) {
+		t.Parallel()
+
+		sv := &selfValuer{}
+		assert.Equal(t, sv, resolveLogValue(sv))
+	})
+	t.Run("panicking LogValuer should not panic", func(t *testing.T) {
+		t.Parallel()
+
+		value := Lazy(func() interface{} {
+			panic("expected panic")
+		})
+		assert.Equal(t, "%!v(PANIC=LogValue method: expected panic)", resolveLogValue(value))
+	})
+}
+
+func TestResolveLogValues(t *testing.T) {
+	t.Parallel()
+
+	args := []interface{}{"a", 1}
+	assert.Equal(t, &args[0], &resolveLogValues(args)[0])
+
+	argsWithValuer := []interface{}{"a", 1, "b", Lazy(func() interface{} { return 2 })}
+	assert.Equal(t, []interface{}{"a", 1, "b", 2}, resolveLogValues(argsWithValuer))
+	assert.IsType(t, Lazy(nil), argsWithValuer[3])
+}
@@ -0,0 +1,171 @@
+package logger
+
+import (
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/Dharitri-org/me-core-logger-go/proto"
+)
+
+const (
+	logfmtKeyTimestamp = "ts"
+	logfmtKeyLevel     = "level"
+	logfmtKeyLogger    = "logger"
+	logfmtKeyShard     = "shard"
+	logfmtKeyEpoch     = "epoch"
+	logfmtKeyRound     = "round"
+	logfmtKeySubRound  = "subround"
+	logfmtKeyCaller    = "caller"
+	logfmtKeyFunction  = "func"
+	logfmtKeyStack     = "stacktrace"
+	logfmtKeyMessage   = "msg"
+	logfmtKeyBadKey    = "!BADKEY"
+	logfmtEmptyKey     = "_"
+)
+
+// LogfmtFormatter implements formatter interface and is used to format log lines in the logfmt form:
+//
+//	ts=2006-01-02T15:04:05.999999999Z level=INFO logger=main shard=0 epoch=1 round=2 subround=(START_ROUND) caller=process/block.go:12 func=process.(*block).Process msg="the message" key1=value1 key2=value2
+//
+// The timestamp is written in the RFC3339Nano format, in UTC. The logger name and the correlation fields follow
+// the global toggles unless overridden by the LoggerName and Correlation options. The caller and func fields are
+// written only for the log lines having a caller location (see ToggleCaller) and the stacktrace field only for the
+// log lines having a stack trace (see SetStackTracePattern). The msg field is always written,
+// all the pairs after it being the arguments.
+// The values that are empty or contain spaces, control characters, '=' or '"' are written quoted, using the
+// \", \\, \n, \r, \t and \uXXXX escapes. The characters not allowed in keys (spaces, control characters, '=' and
+// '"') are replaced by '_' and an empty key is written as "_". On an odd number of arguments, the last argument is
+// written under the "!BADKEY" key. The lines can be read back by ParseLogfmtLine.
+type LogfmtFormatter struct {
+	LoggerName  FieldVisibility
+	Correlation FieldVisibility
+}
+
+// Output converts the provided LogLineHandler into a slice of bytes ready for output
+func (lf *LogfmtFormatter) Output(line LogLineHandler) []byte {
+	if line == nil {
+		return nil
+	}
+
+	return lf.AppendOutput(make([]byte, 0, 256), line)
+}
+
+// AppendOutput appends the formatted LogLineHandler to the provided buffer and returns the extended buffer
+func (lf *LogfmtFormatter) AppendOutput(buff []byte, line LogLineHandler) []byte {
+	if line == nil {
+		return buff
+	}
+
+	// the RFC3339Nano timestamp never needs quoting
+	buff = append(buff, logfmtKeyTimestamp...)
+	buff = append(buff, '=')
+	buff = time.Unix(0, line.GetTimestamp()).UTC().AppendFormat(buff, time.RFC3339Nano)
+	buff = appendLogfmtPair(buff, logfmtKeyLevel, strings.TrimSpace(LogLevel(line.GetLogLevel()).String()))
+	if lf.LoggerName.isShown(IsEnabledLoggerName) {
+		buff = appendLogfmtPair(buff, logfmtKeyLogger, line.GetLoggerName())
+	}
+	if lf.Correlation.isShown(IsEnabledCorrelation) {
+		correlation := line.GetCorrelation()
+		buff = appendLogfmtPair(buff, logfmtKeyShard, correlation.GetShard())
+		buff = appendLogfmtKey(buff, logfmtKeyEpoch)
+		buff = strconv.AppendUint(buff, uint64(correlation.GetEpoch()), 10)
+		buff = appendLogfmtKey(buff, logfmtKeyRound)
+		buff = strconv.AppendInt(buff, correlation.GetRound(), 10)
+		buff = appendLogfmtPair(buff, logfmtKeySubRound, correlation.GetSubRound())
+	}
+	caller := getCaller(line)
+	if hasCaller(caller) {
+		buff = appendLogfmtCaller(buff, caller)
+		buff = appendLogfmtPair(buff, logfmtKeyFunction, callerFunction(caller))
+	}
+	stackTrace := getStackTrace(line)
+	if len(stackTrace) > 0 {
+		buff = appendLogfmtPair(buff, logfmtKeyStack, stackTrace)
+	}
+	buff = appendLogfmtPair(buff, logfmtKeyMessage, line.GetMessage())
+
+	args := line.GetArgs()
+	for index := 1; index < len(args); index += 2 {
+		buff = appendLogfmtPair(buff, sanitizeLogfmtKey(args[index-1]), args[index])
+	}
+	if len(args)%2 == 1 {
+		buff = appendLogfmtPair(buff, logfmtKeyBadKey, args[len(args)-1])
+	}
+
+	buff = append(buff, ASCIINewLine)
+
+	return buff
+}
+
+func appendLogfmtKey(buff []byte, key string) []byte {
+	buff = append(buff, ASCIISpace)
+	buff = append(buff, key...)
+	return append(buff, '=')
+}
+
+func appendLogfmtCaller(buff []byte, caller proto.LogCallerMessage) []byte {
+	buff = appendLogfmtKey(buff, logfmtKeyCaller)
+	file := callerFile(caller)
+	if len(file) > 0 && !needsLogfmtQuoting(file) {
+		buff = append(buff, file...)
+		return appendCallerLine(buff, caller)
+	}
+
+	buff = append(buff, '"')
+	buff = appendJSONStringContent(buff, file)
+	buff = appendCallerLine(buff, caller)
+
+	return append(buff, '"')
+}
+
+func appendLogfmtPair(buff []byte, key string, value string) []byte {
+	buff = appendLogfmtKey(buff, key)
+	if !needsLogfmtQuoting(value) {
+		return append(buff, value...)
+	}
+
+	return appendJSONString(buff, value)
+}
+
+func needsLogfmtQuoting(value string) bool {
+	if len(value) == 0 {
+		return true
+	}
+
+	for i := 0; i < len(value); i++ {
+		if !isLogfmtKeyByte(value[i]) {
+			return true
+		}
+	}
+
+	return !utf8.ValidString(value)
+}
+
+func isLogfmtKeyByte(c byte) bool {
+	return c > ASCIISpace && c != '=' && c != '"' && c != 0x7f
+}
+
+func sanitizeLogfmtKey(key string) string {
+	if len(key) == 0 {
+		return logfmtEmptyKey
+	}
+	if !needsLogfmtQuoting(key) {
+		return key
+	}
+
+	sanitized := []byte(strings.ToValidUTF8(key, logfmtEmptyKey))
+	for i, c := range sanitized {
+		if !isLogfmtKeyByte(c) {
+			sanitized[i] = logfmtEmptyKey[0]
+		}
+	}
+
+	return string(sanitized)
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (lf *LogfmtFormatter) IsInterfaceNil() bool {
+	return lf == nil
+}
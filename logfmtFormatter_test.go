@@ -0,0 +1,171 @@
+package logger_test
+
+import (
+	"errors"
+	"testing"
+
+	logger "github.com/Dharitri-org/me-core-logger-go"
+	"github.com/Dharitri-org/me-core-logger-go/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLogfmtFormatter_OutputNilLineShouldReturnNil(t *testing.T) {
+	t.Parallel()
+
+	lf := &logger.LogfmtFormatter{}
+
+	assert.False(t, lf.IsInterfaceNil())
+	assert.Nil(t, lf.Output(nil))
+}
+
+func TestLogfmtFormatter_OutputShouldQuoteWhenNeeded(t *testing.T) {
+	t.Parallel()
+
+	lf := &logger.LogfmtFormatter{
+		LoggerName:  logger.FieldShown,
+		Correlation: logger.FieldShown,
+	}
+
+	buff := lf.Output(createFormatterTestLogLine(
+		"block processed",
+		logger.LogInfo,
+		"hash", "0a0b",
+		"empty", "",
+		"with space", "a b",
+		"equal", "a=b",
+		"quoted", "say \"hi\"\n",
+		"",
+	))
+
+	expected := `ts=2023-01-02T03:04:05.123456789Z level=INFO logger=process/sync shard=metachain epoch=3 round=42 ` +
+		`subround=(START_ROUND) msg="block processed" hash=0a0b empty="" with_space="a b" equal="a=b" ` +
+		`quoted="say \"hi\"\n" !BADKEY=""` + "\n"
+	assert.Equal(t, expected, string(buff))
+}
+
+func TestLogfmtFormatter_OutputHiddenFields(t *testing.T) {
+	t.Parallel()
+
+	lf := &logger.LogfmtFormatter{
+		LoggerName:  logger.FieldHidden,
+		Correlation: logger.FieldHidden,
+	}
+
+	buff := lf.Output(createFormatterTestLogLine("block processed", logger.LogInfo))
+
+	assert.Equal(t, "ts=2023-01-02T03:04:05.123456789Z level=INFO msg=\"block processed\"\n", string(buff))
+}
+
+func TestParseLogfmtLine_RoundTrip(t *testing.T) {
+	t.Parallel()
+
+	lf := &logger.LogfmtFormatter{
+		LoggerName:  logger.FieldShown,
+		Correlation: logger.FieldShown,
+	}
+	line := createFormatterTestLogLine(
+		"block processed",
+		logger.LogInfo,
+		"level", "not the level",
+		"value", "tab\tcontrol\x01del\x7f unicode ăîș invalid\xff",
+		"empty", "",
+		"dangling",
+	)
+
+	logLine, err := logger.ParseLogfmtLine(string(lf.Output(line)))
+	require.Nil(t, err)
+
+	assert.Equal(t, "process/sync", logLine.LoggerName)
+	assert.Equal(t, line.Correlation, logLine.Correlation)
+	assert.Equal(t, "block processed", logLine.Message)
+	assert.Equal(t, logger.LogInfo, logLine.LogLevel)
+	assert.Equal(t, line.Timestamp, logLine.Timestamp.UnixNano())
+	expectedArgs := []interface{}{
+		"level", "not the level",
+		"value", "tab\tcontrol\x01del\x7f unicode ăîș invalid�",
+		"empty", "",
+		"dangling",
+	}
+	assert.Equal(t, expectedArgs, logLine.Args)
+}
+
+func TestParseLogfmtLine_KeyWithoutValue(t *testing.T) {
+	t.Parallel()
+
+	logLine, err := logger.ParseLogfmtLine("level=warn msg=test flag other=1")
+	require.Nil(t, err)
+
+	assert.Equal(t, logger.LogWarning, logLine.LogLevel)
+	assert.Equal(t, []interface{}{"flag", "", "other", "1"}, logLine.Args)
+}
+
+func TestParseLogfmtLine_InvalidLinesShouldError(t *testing.T) {
+	t.Parallel()
+
+	invalidLines := []string{
+		`msg="unterminated`,
+		`msg="bad escape \q"`,
+		`=value`,
+		`key=a=b`,
+		`level=LOUD msg=test`,
+		`epoch=-1 msg=test`,
+		`round=x msg=test`,
+		`ts=yesterday msg=test`,
+	}
+
+	for _, line := range invalidLines {
+		logLine, err := logger.ParseLogfmtLine(line)
+		assert.Nil(t, logLine, line)
+		assert.True(t, errors.Is(err, logger.ErrInvalidLogfmtLine), line)
+	}
+}
+
+func TestParseLogfmtLine_CallerRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	lf := &logger.LogfmtFormatter{}
+	line := createFormatterTestLogLine("block processed", logger.LogInfo, "k", "v")
+	line.Caller = proto.LogCallerMessage{
+		File:     "/go/src/process/block.go",
+		Line:     12,
+		Function: "github.com/multiversx/process.(*block).Process",
+	}
+
+	buff := lf.Output(line)
+	assert.Contains(t, string(buff), " caller=process/block.go:12 func=process.(*block).Process msg=")
+
+	logLine, err := logger.ParseLogfmtLine(string(buff))
+	require.Nil(t, err)
+	expectedCaller := proto.LogCallerMessage{
+		File:     "process/block.go",
+		Line:     12,
+		Function: "process.(*block).Process",
+	}
+	assert.Equal(t, expectedCaller, logLine.Caller)
+	assert.Equal(t, []interface{}{"k", "v"}, logLine.Args)
+
+	line.Caller.File = "/go/src/my process/block.go"
+	logLine, err = logger.ParseLogfmtLine(string(lf.Output(line)))
+	require.Nil(t, err)
+	assert.Equal(t, "my process/block.go", logLine.Caller.File)
+
+	_, err = logger.ParseLogfmtLine("caller=block.go msg=a")
+	assert.True(t, errors.Is(err, logger.ErrInvalidLogfmtLine))
+}
+
+func TestParseLogfmtLine_StackTraceRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	lf := &logger.LogfmtFormatter{}
+	line := createFormatterTestLogLine("block processed", logger.LogInfo, "k", "v")
+	line.StackTrace = "\tmain.main\n\t\t/src/main.go:7\n"
+
+	buff := lf.Output(line)
+	assert.Contains(t, string(buff), ` stacktrace="\tmain.main\n\t\t/src/main.go:7\n" msg=`)
+
+	logLine, err := logger.ParseLogfmtLine(string(buff))
+	require.Nil(t, err)
+	assert.Equal(t, line.StackTrace, logLine.StackTrace)
+	assert.Equal(t, []interface{}{"k", "v"}, logLine.Args)
+}
@@ -0,0 +1,163 @@
+package logger
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Dharitri-org/me-core-logger-go/proto"
+)
+
+type logfmtPair struct {
+	key   string
+	value string
+}
+
+// ParseLogfmtLine reads back a line written by the LogfmtFormatter. The fields before the msg key are recognized
+// by their keys (ts, level, logger, shard, epoch, round, subround, caller, func and stacktrace), all the pairs after
+// the msg key become the arguments of the returned log line, as strings. A "!BADKEY" pair is read back as a single
+// dangling argument. A key written without a value is read as having the empty value.
+func ParseLogfmtLine(line string) (*LogLine, error) {
+	pairs, err := splitLogfmtPairs(strings.TrimRight(line, "\r\n"))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidLogfmtLine, err.Error())
+	}
+
+	logLine := &LogLine{
+		Args: make([]interface{}, 0),
+	}
+	isReadingArgs := false
+	for _, pair := range pairs {
+		if isReadingArgs {
+			logLine.Args = appendLogfmtArg(logLine.Args, pair)
+			continue
+		}
+
+		err = setLogfmtField(logLine, pair)
+		if err != nil {
+			return nil, fmt.Errorf("%w: key '%s': %s", ErrInvalidLogfmtLine, pair.key, err.Error())
+		}
+		isReadingArgs = pair.key == logfmtKeyMessage
+	}
+
+	return logLine, nil
+}
+
+func appendLogfmtArg(args []interface{}, pair logfmtPair) []interface{} {
+	if pair.key == logfmtKeyBadKey {
+		return append(args, pair.value)
+	}
+
+	return append(args, pair.key, pair.value)
+}
+
+func setLogfmtField(logLine *LogLine, pair logfmtPair) error {
+	var err error
+
+	switch pair.key {
+	case logfmtKeyTimestamp:
+		logLine.Timestamp, err = time.Parse(time.RFC3339Nano, pair.value)
+	case logfmtKeyLevel:
+		logLine.LogLevel, err = GetLogLevel(pair.value)
+	case logfmtKeyLogger:
+		logLine.LoggerName = pair.value
+	case logfmtKeyShard:
+		logLine.Correlation.Shard = pair.value
+	case logfmtKeyEpoch:
+		var epoch uint64
+		epoch, err = strconv.ParseUint(pair.value, 10, 32)
+		logLine.Correlation.Epoch = uint32(epoch)
+	case logfmtKeyRound:
+		logLine.Correlation.Round, err = strconv.ParseInt(pair.value, 10, 64)
+	case logfmtKeySubRound:
+		logLine.Correlation.SubRound = pair.value
+	case logfmtKeyCaller:
+		logLine.Caller, err = parseLogfmtCaller(logLine.Caller, pair.value)
+	case logfmtKeyFunction:
+		logLine.Caller.Function = pair.value
+	case logfmtKeyStack:
+		logLine.StackTrace = pair.value
+	case logfmtKeyMessage:
+		logLine.Message = pair.value
+	default:
+		logLine.Args = appendLogfmtArg(logLine.Args, pair)
+	}
+
+	return err
+}
+
+func parseLogfmtCaller(caller proto.LogCallerMessage, value string) (proto.LogCallerMessage, error) {
+	lineStart := strings.LastIndexByte(value, ':')
+	if lineStart < 0 {
+		return caller, errMissingCallerLine
+	}
+
+	line, err := strconv.ParseInt(value[lineStart+1:], 10, 32)
+	if err != nil {
+		return caller, err
+	}
+
+	caller.File = value[:lineStart]
+	caller.Line = int32(line)
+
+	return caller, nil
+}
+
+func splitLogfmtPairs(line string) ([]logfmtPair, error) {
+	pairs := make([]logfmtPair, 0)
+	for {
+		line = strings.TrimLeft(line, " \t")
+		if len(line) == 0 {
+			return pairs, nil
+		}
+
+		keyEnd := 0
+		for keyEnd < len(line) && isLogfmtKeyByte(line[keyEnd]) {
+			keyEnd++
+		}
+		if keyEnd == 0 {
+			return nil, fmt.Errorf("unexpected character '%c'", line[0])
+		}
+
+		pair := logfmtPair{
+			key: line[:keyEnd],
+		}
+		line = line[keyEnd:]
+		if !strings.HasPrefix(line, "=") {
+			pairs = append(pairs, pair)
+			continue
+		}
+		line = line[1:]
+
+		var err error
+		pair.value, line, err = readLogfmtValue(line)
+		if err != nil {
+			return nil, fmt.Errorf("key '%s': %w", pair.key, err)
+		}
+		pairs = append(pairs, pair)
+	}
+}
+
+func readLogfmtValue(line string) (string, string, error) {
+	if !strings.HasPrefix(line, `"`) {
+		valueEnd := 0
+		for valueEnd < len(line) && isLogfmtKeyByte(line[valueEnd]) {
+			valueEnd++
+		}
+
+		return line[:valueEnd], line[valueEnd:], nil
+	}
+
+	for i := 1; i < len(line); i++ {
+		switch line[i] {
+		case '\\':
+			i++
+		case '"':
+			value, err := strconv.Unquote(line[:i+1])
+			return value, line[i+1:], err
+		}
+	}
+
+	return "", "", errUnterminatedQuotedValue
+}
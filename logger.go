@@ -1,24 +1,52 @@
 package logger
 
 import (
+	"context"
 	"sync"
+	"time"
 )
 
 var _ Logger = (*logger)(nil)
+var _ ContextLogger = (*logger)(nil)
+var _ FieldsLogger = (*logger)(nil)
+var _ BindingLogger = (*logger)(nil)
+var _ LevelEnabler = (*logger)(nil)
+var _ ExitLogger = (*logger)(nil)
+
+// sharedLogLevel holds the log level, the stack trace level and the sampler of a logger, shared with all the loggers
+// derived from it
+type sharedLogLevel struct {
+	mutLevel        sync.RWMutex
+	logLevel        LogLevel
+	stackTraceLevel LogLevel
+	sampler         *messageSampler
+}
+
+// logLineOutput is the part of a log output component used by a logger
+type logLineOutput interface {
+	Output(line *LogLine)
+}
 
 // logger is the primary structure used to interact with the productive code
 type logger struct {
 	name      string
-	mutLevel  sync.RWMutex
-	logLevel  LogLevel
-	logOutput LogOutputHandler
+	level     *sharedLogLevel
+	logOutput logLineOutput
+	boundArgs []interface{}
 }
 
 // NewLogger create a new logger instance
 func NewLogger(name string, logLevel LogLevel, logOutput LogOutputHandler) *logger {
+	return newLogger(name, logLevel, logOutput)
+}
+
+func newLogger(name string, logLevel LogLevel, logOutput logLineOutput) *logger {
 	log := &logger{
-		name:      name,
-		logLevel:  logLevel,
+		name: name,
+		level: &sharedLogLevel{
+			logLevel:        logLevel,
+			stackTraceLevel: LogNone,
+		},
 		logOutput: logOutput,
 	}
 
@@ -26,50 +54,327 @@ func NewLogger(name string, logLevel LogLevel, logOutput LogOutputHandler) *logg
 }
 
 func (l *logger) shouldSkipOutput(compareLogLevel LogLevel) bool {
-	l.mutLevel.RLock()
-	shouldOutput := l.logLevel > compareLogLevel
-	l.mutLevel.RUnlock()
+	l.level.mutLevel.RLock()
+	shouldOutput := l.level.logLevel > compareLogLevel
+	l.level.mutLevel.RUnlock()
 
 	return shouldOutput
 }
 
-func (l *logger) outputMessageFromLogLevel(level LogLevel, message string, args ...interface{}) {
-	if l.shouldSkipOutput(level) {
+// shouldAttachStackTrace returns true if the log line should get a stack trace based on its level
+func (l *logger) shouldAttachStackTrace(logLevel LogLevel) bool {
+	l.level.mutLevel.RLock()
+	stackTraceLevel := l.level.stackTraceLevel
+	l.level.mutLevel.RUnlock()
+
+	return stackTraceLevel != LogNone && logLevel >= stackTraceLevel
+}
+
+// shouldAttachStackTraceOnError returns true if the log line should get a stack trace because it holds an error
+func shouldAttachStackTraceOnError(err error, args []interface{}, fields []Field) bool {
+	if !IsEnabledStackTraceOnErrorArgs() {
+		return false
+	}
+
+	return err != nil || hasErrorArg(args) || hasErrorField(fields)
+}
+
+func (l *logger) setSampler(sampler *messageSampler) {
+	l.level.mutLevel.Lock()
+	l.level.sampler = sampler
+	l.level.mutLevel.Unlock()
+}
+
+func (l *logger) getSampler() *messageSampler {
+	l.level.mutLevel.RLock()
+	defer l.level.mutLevel.RUnlock()
+
+	return l.level.sampler
+}
+
+// replaceSampler sets a new sampler having the provided parameters, unless the current sampler already has them.
+// It returns the replaced sampler, if any, so its pending summaries can be output
+func (l *logger) replaceSampler(config *samplingConfig) *messageSampler {
+	l.level.mutLevel.Lock()
+	defer l.level.mutLevel.Unlock()
+
+	previous := l.level.sampler
+	if previous != nil && config != nil && previous.config == *config {
+		return nil
+	}
+	l.level.sampler = newMessageSampler(config)
+
+	return previous
+}
+
+// shouldSample returns true if the log line is not suppressed by the logger's sampler, if any. The summaries of
+// the ended sampling interval are output first
+func (l *logger) shouldSample(level LogLevel, message string) bool {
+	sampler := l.getSampler()
+	if sampler == nil {
+		return true
+	}
+
+	isSampled, summaries := sampler.sample(level, message, time.Now())
+	for _, summary := range summaries {
+		l.outputSamplingSummary(summary)
+	}
+
+	return isSampled
+}
+
+// outputPendingSamplingSummaries outputs the summaries of the provided sampler's ended tick interval or, if force is
+// set, the summaries of its current tick interval
+func (l *logger) outputPendingSamplingSummaries(sampler *messageSampler, force bool) {
+	if sampler == nil {
+		return
+	}
+
+	for _, summary := range sampler.pendingSummaries(time.Now(), force) {
+		l.outputSamplingSummary(summary)
+	}
+}
+
+func (l *logger) outputSamplingSummary(summary samplingSummary) {
+	if l.shouldSkipOutput(summary.level) {
+		return
+	}
+
+	logLine := acquireLogLine()
+	logLine.LoggerName = l.name
+	logLine.Correlation = GetCorrelation()
+	logLine.Message = samplingSummaryMessage
+	logLine.LogLevel = summary.level
+	logLine.Args = append(logLine.Args[:0],
+		samplingSummaryKeyMessage, summary.message,
+		samplingSummaryKeyCount, summary.suppressed,
+		samplingSummaryKeyTick, summary.interval,
+	)
+	logLine.Timestamp = time.Now()
+
+	l.output(logLine)
+}
+
+func (l *logger) setStackTraceLevel(stackTraceLevel LogLevel) {
+	l.level.mutLevel.Lock()
+	l.level.stackTraceLevel = stackTraceLevel
+	l.level.mutLevel.Unlock()
+}
+
+// outputMessageFromLogLevel outputs the log line if its level is enabled. The err parameter is the error of
+// LogIfError, whose wrapped causes are unrolled when a stack trace is attached
+func (l *logger) outputMessageFromLogLevel(ctx context.Context, level LogLevel, message string, err error, args ...interface{}) {
+	if l.shouldSkipOutput(level) || !l.shouldSample(level, message) {
 		return
 	}
 
-	logLine := newLogLine(l.name, GetCorrelation(), message, level, args...)
+	logLine := acquireLogLine()
+	logLine.LoggerName = l.name
+	logLine.Correlation = GetCorrelationFromContext(ctx)
+	logLine.Message = message
+	logLine.LogLevel = level
+	logLine.Args = l.withBoundArgs(args)
+	logLine.Timestamp = time.Now()
+	logLine.Caller = captureCaller(callerSkip)
+	if l.shouldAttachStackTrace(level) || shouldAttachStackTraceOnError(err, logLine.Args, nil) {
+		logLine.Args = appendErrorCausesArgs(logLine.Args, err, errorFieldKey)
+		logLine.StackTrace = captureStackTrace(callerSkip)
+	}
+
+	l.output(logLine)
+}
+
+func (l *logger) outputFields(level LogLevel, message string, fields []Field) {
+	if l.shouldSkipOutput(level) || !l.shouldSample(level, message) {
+		return
+	}
+
+	logLine := acquireLogLine()
+	logLine.LoggerName = l.name
+	logLine.Correlation = GetCorrelation()
+	logLine.Message = message
+	logLine.LogLevel = level
+	logLine.Args = l.boundArgs
+	logLine.Fields = append(logLine.Fields[:0], fields...)
+	logLine.Timestamp = time.Now()
+	logLine.Caller = captureCaller(callerSkip)
+	if l.shouldAttachStackTrace(level) || shouldAttachStackTraceOnError(nil, logLine.Args, logLine.Fields) {
+		logLine.Args = appendErrorCausesArgs(logLine.Args, nil, errorFieldKey)
+		logLine.Fields = appendErrorCausesFields(logLine.Fields, nil, errorFieldKey)
+		logLine.StackTrace = captureStackTrace(callerSkip)
+	}
+
+	l.output(logLine)
+}
+
+// output hands the pooled log line to the log output. The log line is put back in its pool only if the log output
+// belongs to this package, as the other LogOutputHandler implementations might retain it
+func (l *logger) output(logLine *LogLine) {
 	l.logOutput.Output(logLine)
+
+	switch l.logOutput.(type) {
+	case *logOutputSubject, *slogOutput:
+		releaseLogLine(logLine)
+	}
+}
+
+func (l *logger) withBoundArgs(args []interface{}) []interface{} {
+	if len(l.boundArgs) == 0 {
+		return args
+	}
+
+	allArgs := make([]interface{}, 0, len(l.boundArgs)+len(args))
+	allArgs = append(allArgs, l.boundArgs...)
+
+	return append(allArgs, args...)
+}
+
+// With returns a derived logger that has the same name and shares the log level with this logger but adds the
+// provided key/value pairs in front of the arguments of each produced log line. The arguments must be provided in
+// the following format: "name1", "val1", "name2", "val2" ... An odd number of arguments will have the last
+// argument bound as a name with a nil value.
+func (l *logger) With(args ...interface{}) Logger {
+	boundArgs := l.withBoundArgs(args)
+	if len(boundArgs)%2 != 0 {
+		boundArgs = append(boundArgs, nil)
+	}
+
+	return &logger{
+		name:      l.name,
+		level:     l.level,
+		logOutput: l.logOutput,
+		boundArgs: boundArgs,
+	}
 }
 
 // Trace outputs a tracing log message with optional provided arguments
 func (l *logger) Trace(message string, args ...interface{}) {
-	l.outputMessageFromLogLevel(LogTrace, message, args...)
+	l.outputMessageFromLogLevel(context.Background(), LogTrace, message, nil, args...)
 }
 
 // Debug outputs a debugging log message with optional provided arguments
 func (l *logger) Debug(message string, args ...interface{}) {
-	l.outputMessageFromLogLevel(LogDebug, message, args...)
+	l.outputMessageFromLogLevel(context.Background(), LogDebug, message, nil, args...)
 }
 
 // Info outputs an information log message with optional provided arguments
 func (l *logger) Info(message string, args ...interface{}) {
-	l.outputMessageFromLogLevel(LogInfo, message, args...)
+	l.outputMessageFromLogLevel(context.Background(), LogInfo, message, nil, args...)
 }
 
 // Warn outputs a warning log message with optional provided arguments
 func (l *logger) Warn(message string, args ...interface{}) {
-	l.outputMessageFromLogLevel(LogWarning, message, args...)
+	l.outputMessageFromLogLevel(context.Background(), LogWarning, message, nil, args...)
 }
 
 // Error outputs an error log message with optional provided arguments
 func (l *logger) Error(message string, args ...interface{}) {
-	l.outputMessageFromLogLevel(LogError, message, args...)
+	l.outputMessageFromLogLevel(context.Background(), LogError, message, nil, args...)
+}
+
+// Panic outputs a panic log message with optional provided arguments, runs the exit hooks, flushes the outputs and
+// then panics with the message. The outputs are not closed, as the panic can be recovered
+func (l *logger) Panic(message string, args ...interface{}) {
+	l.outputMessageFromLogLevel(context.Background(), LogPanic, message, nil, args...)
+	prepareExit(l.logOutput, false)
+
+	panic(message)
+}
+
+// Fatal outputs a fatal log message with optional provided arguments, runs the exit hooks, flushes and closes the
+// outputs and then ends the process with the exit code 1 (see SetExitHandler)
+func (l *logger) Fatal(message string, args ...interface{}) {
+	l.outputMessageFromLogLevel(context.Background(), LogFatal, message, nil, args...)
+	prepareExit(l.logOutput, true)
+
+	exitProcess(fatalExitCode)
 }
 
 // Log outputs a defined log level message with optional provided arguments
 func (l *logger) Log(logLevel LogLevel, message string, args ...interface{}) {
-	l.outputMessageFromLogLevel(logLevel, message, args...)
+	l.outputMessageFromLogLevel(context.Background(), logLevel, message, nil, args...)
+}
+
+// TraceFields outputs a tracing log message with the provided typed fields
+func (l *logger) TraceFields(message string, fields ...Field) {
+	l.outputFields(LogTrace, message, fields)
+}
+
+// DebugFields outputs a debugging log message with the provided typed fields
+func (l *logger) DebugFields(message string, fields ...Field) {
+	l.outputFields(LogDebug, message, fields)
+}
+
+// InfoFields outputs an information log message with the provided typed fields
+func (l *logger) InfoFields(message string, fields ...Field) {
+	l.outputFields(LogInfo, message, fields)
+}
+
+// WarnFields outputs a warning log message with the provided typed fields
+func (l *logger) WarnFields(message string, fields ...Field) {
+	l.outputFields(LogWarning, message, fields)
+}
+
+// ErrorFields outputs an error log message with the provided typed fields
+func (l *logger) ErrorFields(message string, fields ...Field) {
+	l.outputFields(LogError, message, fields)
+}
+
+// LogFields outputs a defined log level message with the provided typed fields
+func (l *logger) LogFields(logLevel LogLevel, message string, fields ...Field) {
+	l.outputFields(logLevel, message, fields)
+}
+
+// TraceCtx outputs a tracing log message with optional provided arguments using the correlation elements from
+// the provided context
+func (l *logger) TraceCtx(ctx context.Context, message string, args ...interface{}) {
+	l.outputMessageFromLogLevel(ctx, LogTrace, message, nil, args...)
+}
+
+// DebugCtx outputs a debugging log message with optional provided arguments using the correlation elements from
+// the provided context
+func (l *logger) DebugCtx(ctx context.Context, message string, args ...interface{}) {
+	l.outputMessageFromLogLevel(ctx, LogDebug, message, nil, args...)
+}
+
+// InfoCtx outputs an information log message with optional provided arguments using the correlation elements from
+// the provided context
+func (l *logger) InfoCtx(ctx context.Context, message string, args ...interface{}) {
+	l.outputMessageFromLogLevel(ctx, LogInfo, message, nil, args...)
+}
+
+// WarnCtx outputs a warning log message with optional provided arguments using the correlation elements from
+// the provided context
+func (l *logger) WarnCtx(ctx context.Context, message string, args ...interface{}) {
+	l.outputMessageFromLogLevel(ctx, LogWarning, message, nil, args...)
+}
+
+// ErrorCtx outputs an error log message with optional provided arguments using the correlation elements from
+// the provided context
+func (l *logger) ErrorCtx(ctx context.Context, message string, args ...interface{}) {
+	l.outputMessageFromLogLevel(ctx, LogError, message, nil, args...)
+}
+
+// LogCtx outputs a defined log level message with optional provided arguments using the correlation elements from
+// the provided context
+func (l *logger) LogCtx(ctx context.Context, logLevel LogLevel, message string, args ...interface{}) {
+	l.outputMessageFromLogLevel(ctx, logLevel, message, nil, args...)
+}
+
+// PanicCtx behaves as Panic, using the correlation elements from the provided context
+func (l *logger) PanicCtx(ctx context.Context, message string, args ...interface{}) {
+	l.outputMessageFromLogLevel(ctx, LogPanic, message, nil, args...)
+	prepareExit(l.logOutput, false)
+
+	panic(message)
+}
+
+// FatalCtx behaves as Fatal, using the correlation elements from the provided context
+func (l *logger) FatalCtx(ctx context.Context, message string, args ...interface{}) {
+	l.outputMessageFromLogLevel(ctx, LogFatal, message, nil, args...)
+	prepareExit(l.logOutput, true)
+
+	exitProcess(fatalExitCode)
 }
 
 // LogIfError outputs an error log message with optional provided arguments if the provided error parameter is not nil
@@ -78,7 +383,7 @@ func (l *logger) LogIfError(err error, args ...interface{}) {
 		return
 	}
 
-	l.Error(err.Error(), args...)
+	l.outputMessageFromLogLevel(context.Background(), LogError, err.Error(), err, args...)
 }
 
 // LogLine forwards the log line towards underlying log output handler
@@ -90,18 +395,24 @@ func (l *logger) LogLine(line *LogLine) {
 	l.logOutput.Output(line)
 }
 
+// IsEnabled returns true if a log message with the provided log level would be output by the logger. It can be
+// used in order to guard the code blocks that compute the arguments of a log message
+func (l *logger) IsEnabled(logLevel LogLevel) bool {
+	return !l.shouldSkipOutput(logLevel)
+}
+
 // SetLevel sets the current level of the logger
 func (l *logger) SetLevel(logLevel LogLevel) {
-	l.mutLevel.Lock()
-	l.logLevel = logLevel
-	l.mutLevel.Unlock()
+	l.level.mutLevel.Lock()
+	l.level.logLevel = logLevel
+	l.level.mutLevel.Unlock()
 }
 
 // GetLevel gets the current level of the logger
 func (l *logger) GetLevel() LogLevel {
-	l.mutLevel.RLock()
-	level := l.logLevel
-	l.mutLevel.RUnlock()
+	l.level.mutLevel.RLock()
+	level := l.level.logLevel
+	l.level.mutLevel.RUnlock()
 	return level
 }
 
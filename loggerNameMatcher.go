@@ -0,0 +1,182 @@
+package logger
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+)
+
+const (
+	selectorsSeparator  = ";"
+	exactPrefix         = "="
+	negationPrefix      = "!"
+	regexPrefix         = "~"
+	globWildcard        = "*"
+	globRecursive       = "**"
+	globRecursiveSuffix = "/" + globRecursive
+)
+
+var errEmptySelector = errors.New("empty selector")
+var errDoubleNegation = errors.New("double negation")
+
+// loggerNameMatcher decides if a logger name is matched by a pattern. The pattern is composed of one or more
+// selectors separated by ';'. Each selector can be:
+//   - "*" or "**" matching all logger names
+//   - "=name" matching only the logger called exactly "name"
+//   - "~expression" matching the logger names on which the regular expression finds a match
+//   - a path glob such as "process/*" or "process/**": '*' matches any characters except '/' while '**' matches
+//     any characters; a trailing "/**" also matches the parent itself ("process/**" matches "process")
+//   - any other string matching the logger names that contain it on any position (the legacy form)
+//
+// The regular expressions can not contain the ';' character.
+// Any of the above can be prefixed by '!' in order to exclude the matching logger names.
+// A logger name is matched if it is matched by at least one of the non-excluding selectors (or if there are only
+// excluding selectors) and it is not matched by any of the excluding selectors.
+// Example: "process/**;!process/sync" matches all loggers from the process subtree except for "process/sync".
+type loggerNameMatcher struct {
+	pattern   string
+	matchAll  bool
+	including []func(name string) bool
+	excluding []func(name string) bool
+}
+
+func newLoggerNameMatcher(pattern string) (*loggerNameMatcher, error) {
+	lnm := &loggerNameMatcher{
+		pattern:   pattern,
+		including: make([]func(name string) bool, 0),
+		excluding: make([]func(name string) bool, 0),
+	}
+
+	if len(pattern) == 0 {
+		// the legacy behavior: the empty string is contained by every logger name
+		return lnm, nil
+	}
+
+	for _, selector := range strings.Split(pattern, selectorsSeparator) {
+		err := lnm.addSelector(selector)
+		if err != nil {
+			return nil, err
+		}
+	}
+	lnm.matchAll = isMatchAllSelector(pattern)
+
+	return lnm, nil
+}
+
+func (lnm *loggerNameMatcher) addSelector(selector string) error {
+	isExcluding := strings.HasPrefix(selector, negationPrefix)
+	selector = strings.TrimPrefix(selector, negationPrefix)
+	if len(selector) == 0 {
+		return errEmptySelector
+	}
+	if strings.HasPrefix(selector, negationPrefix) {
+		return errDoubleNegation
+	}
+
+	selectorFunc, err := compileSelector(selector)
+	if err != nil {
+		return err
+	}
+
+	if isExcluding {
+		lnm.excluding = append(lnm.excluding, selectorFunc)
+		return nil
+	}
+
+	lnm.including = append(lnm.including, selectorFunc)
+	return nil
+}
+
+func compileSelector(selector string) (func(name string) bool, error) {
+	if strings.HasPrefix(selector, regexPrefix) {
+		expression, err := regexp.Compile(strings.TrimPrefix(selector, regexPrefix))
+		if err != nil {
+			return nil, err
+		}
+
+		return expression.MatchString, nil
+	}
+
+	if selector == exactPrefix {
+		return nil, errEmptySelector
+	}
+
+	return createSelectorFunc(selector), nil
+}
+
+func createSelectorFunc(selector string) func(name string) bool {
+	switch {
+	case isMatchAllSelector(selector):
+		return func(_ string) bool { return true }
+	case strings.HasPrefix(selector, exactPrefix):
+		exactName := strings.TrimPrefix(selector, exactPrefix)
+		return func(name string) bool { return name == exactName }
+	case strings.Contains(selector, globWildcard):
+		return globToRegexp(selector).MatchString
+	default:
+		return func(name string) bool { return strings.Contains(name, selector) }
+	}
+}
+
+func isMatchAllSelector(selector string) bool {
+	return selector == globWildcard || selector == globRecursive
+}
+
+func globToRegexp(glob string) *regexp.Regexp {
+	optionalRecursiveSuffix := strings.HasSuffix(glob, globRecursiveSuffix)
+	if optionalRecursiveSuffix {
+		glob = strings.TrimSuffix(glob, globRecursiveSuffix)
+	}
+
+	expression := strings.Builder{}
+	expression.WriteString("^")
+	for len(glob) > 0 {
+		switch {
+		case strings.HasPrefix(glob, globRecursive):
+			expression.WriteString(".*")
+			glob = glob[len(globRecursive):]
+		case strings.HasPrefix(glob, globWildcard):
+			expression.WriteString("[^/]*")
+			glob = glob[len(globWildcard):]
+		default:
+			nextWildcard := strings.Index(glob, globWildcard)
+			if nextWildcard < 0 {
+				nextWildcard = len(glob)
+			}
+			expression.WriteString(regexp.QuoteMeta(glob[:nextWildcard]))
+			glob = glob[nextWildcard:]
+		}
+	}
+	if optionalRecursiveSuffix {
+		expression.WriteString("(/.*)?")
+	}
+	expression.WriteString("$")
+
+	return regexp.MustCompile(expression.String())
+}
+
+// isMatching returns true if the provided logger name is matched by the pattern
+func (lnm *loggerNameMatcher) isMatching(name string) bool {
+	for _, selectorFunc := range lnm.excluding {
+		if selectorFunc(name) {
+			return false
+		}
+	}
+
+	if len(lnm.including) == 0 {
+		return true
+	}
+
+	for _, selectorFunc := range lnm.including {
+		if selectorFunc(name) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isMatchingAll returns true if the pattern matches all the logger names by construction
+func (lnm *loggerNameMatcher) isMatchingAll() bool {
+	return lnm.matchAll
+}
@@ -0,0 +1,90 @@
+package logger
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testMatching(t *testing.T, pattern string, matched []string, notMatched []string) {
+	lnm, err := newLoggerNameMatcher(pattern)
+	require.Nil(t, err)
+
+	for _, name := range matched {
+		assert.True(t, lnm.isMatching(name), "pattern '%s' should match '%s'", pattern, name)
+	}
+	for _, name := range notMatched {
+		assert.False(t, lnm.isMatching(name), "pattern '%s' should not match '%s'", pattern, name)
+	}
+}
+
+func TestLoggerNameMatcher_Substring(t *testing.T) {
+	t.Parallel()
+
+	testMatching(t, "p2p", []string{"p2p", "process/p2pAntiflood"}, []string{"process"})
+	testMatching(t, "", []string{"p2p", ""}, nil)
+}
+
+func TestLoggerNameMatcher_MatchAll(t *testing.T) {
+	t.Parallel()
+
+	for _, pattern := range []string{"*", "**"} {
+		lnm, err := newLoggerNameMatcher(pattern)
+		require.Nil(t, err)
+		assert.True(t, lnm.isMatchingAll())
+		assert.True(t, lnm.isMatching("process/sync"))
+	}
+
+	lnm, _ := newLoggerNameMatcher("process")
+	assert.False(t, lnm.isMatchingAll())
+}
+
+func TestLoggerNameMatcher_Exact(t *testing.T) {
+	t.Parallel()
+
+	testMatching(t, "=process", []string{"process"}, []string{"process/sync", "myprocess"})
+}
+
+func TestLoggerNameMatcher_Globs(t *testing.T) {
+	t.Parallel()
+
+	testMatching(t, "process/*", []string{"process/sync", "process/"}, []string{"process", "process/sync/a", "a/process/sync"})
+	testMatching(t, "process/**", []string{"process", "process/sync", "process/sync/a"}, []string{"processes", "a/process"})
+	testMatching(t, "*/sync", []string{"process/sync", "a/sync"}, []string{"a/b/sync", "process/syncer"})
+	testMatching(t, "a.b/**/c", []string{"a.b/x/c", "a.b/x/y/c"}, []string{"aXb/x/c"})
+}
+
+func TestLoggerNameMatcher_Regex(t *testing.T) {
+	t.Parallel()
+
+	testMatching(t, "~^process/(sync|block)$", []string{"process/sync", "process/block"}, []string{"process/sync/a"})
+}
+
+func TestLoggerNameMatcher_Exclusions(t *testing.T) {
+	t.Parallel()
+
+	testMatching(t, "process/**;!process/sync", []string{"process", "process/block"}, []string{"process/sync", "data"})
+	testMatching(t, "!process", []string{"data", "p2p"}, []string{"process/sync"})
+	testMatching(t, "=a;=b;!~x", []string{"a", "b"}, []string{"c", "x"})
+}
+
+func TestLoggerNameMatcher_InvalidSelectorsShouldErr(t *testing.T) {
+	t.Parallel()
+
+	invalidPatterns := map[string]error{
+		"a;":    errEmptySelector,
+		"!":     errEmptySelector,
+		"=":     errEmptySelector,
+		"!!a":   errDoubleNegation,
+		"a;;b":  errEmptySelector,
+		"!a;!=": errEmptySelector,
+	}
+	for pattern, expectedErr := range invalidPatterns {
+		_, err := newLoggerNameMatcher(pattern)
+		assert.Equal(t, expectedErr, err, "for pattern '%s'", pattern)
+	}
+
+	_, err := newLoggerNameMatcher("~a(")
+	assert.NotNil(t, err)
+}
@@ -1,13 +1,19 @@
 package logger_test
 
 import (
+	"context"
+	"errors"
+	"io"
+	"runtime"
 	"sync/atomic"
 	"testing"
 
 	logger "github.com/Dharitri-org/me-core-logger-go"
 	"github.com/Dharitri-org/me-core-logger-go/mock"
+	"github.com/Dharitri-org/me-core-logger-go/proto"
 	"github.com/Dharitri-org/me-core/core/check"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func generateTestLogOutputSubject() (logger.LogOutputHandler, *int32) {
@@ -301,6 +307,109 @@ func TestLogger_LogLineShouldWork(t *testing.T) {
 	assert.Equal(t, int32(1), atomic.LoadInt32(numCalls))
 }
 
+//------- Context
+
+func TestLogger_CtxMethodsShouldUseTheContextCorrelation(t *testing.T) {
+	t.Parallel()
+
+	los, lines := generateCapturingLogOutputSubject()
+	log := logger.NewLogger("test", logger.LogTrace, los)
+	ctx := logger.ContextWithCorrelationRound(context.Background(), 1234)
+
+	log.TraceCtx(ctx, "message")
+	log.DebugCtx(ctx, "message")
+	log.InfoCtx(ctx, "message")
+	log.WarnCtx(ctx, "message")
+	log.ErrorCtx(ctx, "message")
+	log.LogCtx(ctx, logger.LogInfo, "message")
+
+	require.Equal(t, 6, len(*lines))
+	expectedLevels := []logger.LogLevel{logger.LogTrace, logger.LogDebug, logger.LogInfo, logger.LogWarning, logger.LogError, logger.LogInfo}
+	for i, line := range *lines {
+		assert.Equal(t, int64(1234), line.GetCorrelation().Round)
+		assert.Equal(t, int32(expectedLevels[i]), line.GetLogLevel())
+	}
+}
+
+func TestLogger_CtxMethodsShouldNotCallIfLogLevelIsHigher(t *testing.T) {
+	t.Parallel()
+
+	los, numCalls := generateTestLogOutputSubject()
+	log := logger.NewLogger("test", logger.LogError, los)
+
+	log.InfoCtx(context.Background(), "test")
+
+	assert.Equal(t, int32(0), atomic.LoadInt32(numCalls))
+}
+
+//------- With
+
+func generateCapturingLogOutputSubject() (logger.LogOutputHandler, *[]logger.LogLineHandler) {
+	lines := make([]logger.LogLineHandler, 0)
+	los := logger.NewLogOutputSubject()
+	_ = los.AddObserver(
+		&mock.WriterStub{
+			WriteCalled: func(p []byte) (n int, err error) {
+				return len(p), nil
+			},
+		},
+		&mock.FormatterStub{
+			OutputCalled: func(line logger.LogLineHandler) []byte {
+				lines = append(lines, line)
+				return nil
+			},
+		},
+	)
+
+	return los, &lines
+}
+
+func TestLogger_WithShouldAddBoundArgsToEachLine(t *testing.T) {
+	t.Parallel()
+
+	los, lines := generateCapturingLogOutputSubject()
+	log := logger.NewLogger("test", logger.LogDebug, los)
+
+	derived := log.With("shard", 1, "peer", []byte("pid"))
+	derived.Info("message", "a", "b")
+	derived.Debug("message")
+	log.Info("message", "a", "b")
+
+	require.Equal(t, 3, len(*lines))
+	assert.Equal(t, []string{"shard", "1", "peer", "706964", "a", "b"}, (*lines)[0].GetArgs())
+	assert.Equal(t, []string{"shard", "1", "peer", "706964"}, (*lines)[1].GetArgs())
+	assert.Equal(t, []string{"a", "b"}, (*lines)[2].GetArgs())
+	assert.Equal(t, "test", (*lines)[0].GetLoggerName())
+}
+
+func TestLogger_WithShouldAccumulateAndPadOddArgs(t *testing.T) {
+	t.Parallel()
+
+	los, lines := generateCapturingLogOutputSubject()
+	log := logger.NewLogger("test", logger.LogDebug, los)
+
+	log.With("a", 1).(logger.BindingLogger).With("b").Info("message")
+
+	require.Equal(t, 1, len(*lines))
+	assert.Equal(t, []string{"a", "1", "b", "<nil>"}, (*lines)[0].GetArgs())
+}
+
+func TestLogger_WithShouldShareTheLogLevel(t *testing.T) {
+	t.Parallel()
+
+	los, numCalls := generateTestLogOutputSubject()
+	log := logger.NewLogger("test", logger.LogInfo, los)
+	derived := log.With("a", 1)
+
+	derived.Debug("test")
+	assert.Equal(t, int32(0), atomic.LoadInt32(numCalls))
+
+	log.SetLevel(logger.LogDebug)
+	derived.Debug("test")
+	assert.Equal(t, int32(1), atomic.LoadInt32(numCalls))
+	assert.Equal(t, logger.LogDebug, derived.GetLevel())
+}
+
 func Benchmark_ManyIneffectiveTraces(b *testing.B) {
 	log := logger.GetOrCreate("foobar")
 	log.SetLevel(logger.LogInfo)
@@ -312,3 +421,203 @@ func Benchmark_ManyIneffectiveTraces(b *testing.B) {
 		}
 	}
 }
+
+func BenchmarkLogger_FieldsToPlainFormatter(b *testing.B) {
+	los := logger.NewLogOutputSubject()
+	_ = los.AddObserver(io.Discard, &logger.PlainFormatter{})
+	log := logger.NewLogger("benchmark", logger.LogTrace, los)
+	hash := []byte("0123456789abcdef")
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		log.TraceFields("benchmark message", logger.Uint64("nonce", uint64(i)), logger.Bytes("hash", hash), logger.String("shard", "meta"))
+	}
+}
+
+func BenchmarkLogger_FieldsToJSONFormatter(b *testing.B) {
+	los := logger.NewLogOutputSubject()
+	_ = los.AddObserver(io.Discard, &logger.JSONFormatter{})
+	log := logger.NewLogger("benchmark", logger.LogTrace, los)
+	hash := []byte("0123456789abcdef")
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		log.TraceFields("benchmark message", logger.Uint64("nonce", uint64(i)), logger.Bytes("hash", hash), logger.String("shard", "meta"))
+	}
+}
+
+func BenchmarkLogger_ArgsToPlainFormatter(b *testing.B) {
+	los := logger.NewLogOutputSubject()
+	_ = los.AddObserver(io.Discard, &logger.PlainFormatter{})
+	log := logger.NewLogger("benchmark", logger.LogTrace, los)
+	hash := []byte("0123456789abcdef")
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		log.Trace("benchmark message", "nonce", uint64(i), "hash", hash, "shard", "meta")
+	}
+}
+
+func TestLogger_LazyArgumentsShouldBeResolvedOnlyWhenOutput(t *testing.T) {
+	t.Parallel()
+
+	los, lines := generateCapturingLogOutputSubject()
+	log := logger.NewLogger("test", logger.LogDebug, los)
+
+	numCalls := 0
+	lazy := logger.Lazy(func() interface{} {
+		numCalls++
+		return []byte("pid")
+	})
+
+	log.Trace("message", "lazy", lazy)
+	log.TraceFields("message", logger.Any("lazy", lazy))
+	assert.Equal(t, 0, numCalls)
+
+	log.Debug("message", "lazy", lazy)
+	log.DebugFields("message", logger.Any("lazy", lazy))
+	assert.Equal(t, 2, numCalls)
+
+	require.Equal(t, 2, len(*lines))
+	for _, line := range *lines {
+		assert.Equal(t, []string{"lazy", "706964"}, line.GetArgs())
+		assert.Equal(t, []byte("pid"), logger.LogArgumentValue(line.(logger.TypedArgsHandler).GetTypedArgs()[1]))
+	}
+}
+
+func TestLogger_IsEnabled(t *testing.T) {
+	t.Parallel()
+
+	log := logger.NewLogger("test", logger.LogInfo, logger.NewLogOutputSubject())
+
+	assert.False(t, log.IsEnabled(logger.LogTrace))
+	assert.False(t, log.IsEnabled(logger.LogDebug))
+	assert.True(t, log.IsEnabled(logger.LogInfo))
+	assert.True(t, log.IsEnabled(logger.LogError))
+
+	log.SetLevel(logger.LogTrace)
+	assert.True(t, log.IsEnabled(logger.LogTrace))
+}
+
+func TestLogger_CallerShouldPointToTheCallSite(t *testing.T) {
+	logger.ToggleCaller(true)
+	defer logger.ToggleCaller(false)
+
+	los, lines := generateCapturingLogOutputSubject()
+	log := logger.NewLogger("test", logger.LogTrace, los)
+	derived := log.With("a", 1)
+	ctx := context.Background()
+
+	calls := []func(){
+		func() { log.Trace("message") },
+		func() { log.Debug("message") },
+		func() { log.Info("message") },
+		func() { log.Warn("message") },
+		func() { log.Error("message") },
+		func() { log.Log(logger.LogInfo, "message") },
+		func() { log.TraceCtx(ctx, "message") },
+		func() { log.DebugCtx(ctx, "message") },
+		func() { log.InfoCtx(ctx, "message") },
+		func() { log.WarnCtx(ctx, "message") },
+		func() { log.ErrorCtx(ctx, "message") },
+		func() { log.LogCtx(ctx, logger.LogInfo, "message") },
+		func() { log.TraceFields("message") },
+		func() { log.DebugFields("message") },
+		func() { log.InfoFields("message") },
+		func() { log.WarnFields("message") },
+		func() { log.ErrorFields("message") },
+		func() { log.LogFields(logger.LogInfo, "message") },
+		func() { log.LogIfError(errors.New("message")) },
+		func() { derived.Info("message") },
+	}
+	_, file, firstLine, _ := runtime.Caller(0)
+	firstLine -= len(calls) + 1
+
+	for _, call := range calls {
+		call()
+	}
+
+	require.Equal(t, len(calls), len(*lines))
+	for i, line := range *lines {
+		caller := line.(logger.CallerHandler).GetCaller()
+		assert.Equal(t, file, caller.File)
+		assert.Equal(t, int32(firstLine+i), caller.Line)
+		assert.Contains(t, caller.Function, "TestLogger_CallerShouldPointToTheCallSite")
+	}
+}
+
+func TestLogger_CallerShouldBeEmptyWhenDisabled(t *testing.T) {
+	t.Parallel()
+
+	los, lines := generateCapturingLogOutputSubject()
+	log := logger.NewLogger("test", logger.LogTrace, los)
+
+	log.Info("message")
+
+	require.Equal(t, 1, len(*lines))
+	assert.Equal(t, proto.LogCallerMessage{}, (*lines)[0].(logger.CallerHandler).GetCaller())
+}
+
+//------- retained log lines
+
+type retainingLogOutput struct {
+	lines []*logger.LogLine
+}
+
+func (rlo *retainingLogOutput) Output(line *logger.LogLine) {
+	rlo.lines = append(rlo.lines, line)
+}
+
+func (rlo *retainingLogOutput) AddObserver(_ io.Writer, _ logger.Formatter) error {
+	return nil
+}
+
+func (rlo *retainingLogOutput) RemoveObserver(_ io.Writer) error {
+	return nil
+}
+
+func (rlo *retainingLogOutput) ClearObservers() {
+}
+
+func (rlo *retainingLogOutput) IsInterfaceNil() bool {
+	return rlo == nil
+}
+
+func TestLogger_CustomLogOutputCanRetainTheLogLines(t *testing.T) {
+	t.Parallel()
+
+	output := &retainingLogOutput{}
+	log := logger.NewLogger("test", logger.LogTrace, output)
+
+	log.Info("first", "a", 1)
+	log.InfoFields("second", logger.Int("b", 2))
+	log.Info("third", "c", 3)
+
+	require.Equal(t, 3, len(output.lines))
+	assert.Equal(t, "first", output.lines[0].Message)
+	assert.Equal(t, []interface{}{"a", 1}, output.lines[0].Args)
+	assert.Equal(t, "second", output.lines[1].Message)
+	assert.Equal(t, []logger.Field{logger.Int("b", 2)}, output.lines[1].Fields)
+	assert.Equal(t, "third", output.lines[2].Message)
+}
+
+func TestLogger_CustomFormatterCanRetainTheLogLines(t *testing.T) {
+	t.Parallel()
+
+	los, lines := generateCapturingLogOutputSubject()
+	log := logger.NewLogger("test", logger.LogTrace, los)
+
+	log.Info("first", "a", 1)
+	log.InfoFields("second", logger.Int("b", 2))
+	log.Info("third", "c", 3)
+
+	require.Equal(t, 3, len(*lines))
+	assert.Equal(t, "first", (*lines)[0].GetMessage())
+	assert.Equal(t, []string{"a", "1"}, (*lines)[0].GetArgs())
+	assert.Equal(t, "second", (*lines)[1].GetMessage())
+	assert.Equal(t, []string{"b", "2"}, (*lines)[1].GetArgs())
+	assert.Equal(t, "third", (*lines)[2].GetMessage())
+}
@@ -0,0 +1,42 @@
+package mock
+
+import logger "github.com/Dharitri-org/me-core-logger-go"
+
+// LogLineObserverStub -
+type LogLineObserverStub struct {
+	OutputLogLineCalled func(line logger.LogLineHandler) error
+	FlushCalled         func() error
+	CloseCalled         func() error
+}
+
+// OutputLogLine -
+func (stub *LogLineObserverStub) OutputLogLine(line logger.LogLineHandler) error {
+	if stub.OutputLogLineCalled != nil {
+		return stub.OutputLogLineCalled(line)
+	}
+
+	return nil
+}
+
+// Flush -
+func (stub *LogLineObserverStub) Flush() error {
+	if stub.FlushCalled != nil {
+		return stub.FlushCalled()
+	}
+
+	return nil
+}
+
+// Close -
+func (stub *LogLineObserverStub) Close() error {
+	if stub.CloseCalled != nil {
+		return stub.CloseCalled()
+	}
+
+	return nil
+}
+
+// IsInterfaceNil -
+func (stub *LogLineObserverStub) IsInterfaceNil() bool {
+	return stub == nil
+}
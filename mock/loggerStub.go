@@ -1,19 +1,41 @@
 package mock
 
-import logger "github.com/Dharitri-org/me-core-logger-go"
+import (
+	"context"
+
+	logger "github.com/Dharitri-org/me-core-logger-go"
+)
 
 // LoggerStub -
 type LoggerStub struct {
-	TraceCalled      func(message string, args ...interface{})
-	DebugCalled      func(message string, args ...interface{})
-	InfoCalled       func(message string, args ...interface{})
-	WarnCalled       func(message string, args ...interface{})
-	ErrorCalled      func(message string, args ...interface{})
-	LogIfErrorCalled func(err error, args ...interface{})
-	LogCalled        func(logLevel logger.LogLevel, message string, args ...interface{})
-	LogLineCalled    func(line *logger.LogLine)
-	SetLevelCalled   func(logLevel logger.LogLevel)
-	GetLevelCalled   func() logger.LogLevel
+	TraceCalled       func(message string, args ...interface{})
+	DebugCalled       func(message string, args ...interface{})
+	InfoCalled        func(message string, args ...interface{})
+	WarnCalled        func(message string, args ...interface{})
+	ErrorCalled       func(message string, args ...interface{})
+	PanicCalled       func(message string, args ...interface{})
+	FatalCalled       func(message string, args ...interface{})
+	LogIfErrorCalled  func(err error, args ...interface{})
+	LogCalled         func(logLevel logger.LogLevel, message string, args ...interface{})
+	TraceCtxCalled    func(ctx context.Context, message string, args ...interface{})
+	DebugCtxCalled    func(ctx context.Context, message string, args ...interface{})
+	InfoCtxCalled     func(ctx context.Context, message string, args ...interface{})
+	WarnCtxCalled     func(ctx context.Context, message string, args ...interface{})
+	ErrorCtxCalled    func(ctx context.Context, message string, args ...interface{})
+	LogCtxCalled      func(ctx context.Context, logLevel logger.LogLevel, message string, args ...interface{})
+	PanicCtxCalled    func(ctx context.Context, message string, args ...interface{})
+	FatalCtxCalled    func(ctx context.Context, message string, args ...interface{})
+	TraceFieldsCalled func(message string, fields ...logger.Field)
+	DebugFieldsCalled func(message string, fields ...logger.Field)
+	InfoFieldsCalled  func(message string, fields ...logger.Field)
+	WarnFieldsCalled  func(message string, fields ...logger.Field)
+	ErrorFieldsCalled func(message string, fields ...logger.Field)
+	LogFieldsCalled   func(logLevel logger.LogLevel, message string, fields ...logger.Field)
+	LogLineCalled     func(line *logger.LogLine)
+	IsEnabledCalled   func(logLevel logger.LogLevel) bool
+	WithCalled        func(args ...interface{}) logger.Logger
+	SetLevelCalled    func(logLevel logger.LogLevel)
+	GetLevelCalled    func() logger.LogLevel
 }
 
 // Log -
@@ -30,6 +52,24 @@ func (stub *LoggerStub) LogLine(line *logger.LogLine) {
 	}
 }
 
+// IsEnabled -
+func (stub *LoggerStub) IsEnabled(logLevel logger.LogLevel) bool {
+	if stub.IsEnabledCalled != nil {
+		return stub.IsEnabledCalled(logLevel)
+	}
+
+	return true
+}
+
+// With -
+func (stub *LoggerStub) With(args ...interface{}) logger.Logger {
+	if stub.WithCalled != nil {
+		return stub.WithCalled(args...)
+	}
+
+	return stub
+}
+
 // Trace -
 func (stub *LoggerStub) Trace(message string, args ...interface{}) {
 	if stub.TraceCalled != nil {
@@ -65,6 +105,20 @@ func (stub *LoggerStub) Error(message string, args ...interface{}) {
 	}
 }
 
+// Panic -
+func (stub *LoggerStub) Panic(message string, args ...interface{}) {
+	if stub.PanicCalled != nil {
+		stub.PanicCalled(message, args...)
+	}
+}
+
+// Fatal -
+func (stub *LoggerStub) Fatal(message string, args ...interface{}) {
+	if stub.FatalCalled != nil {
+		stub.FatalCalled(message, args...)
+	}
+}
+
 // LogIfError -
 func (stub *LoggerStub) LogIfError(err error, args ...interface{}) {
 	if stub.LogIfErrorCalled != nil {
@@ -72,6 +126,62 @@ func (stub *LoggerStub) LogIfError(err error, args ...interface{}) {
 	}
 }
 
+// TraceCtx -
+func (stub *LoggerStub) TraceCtx(ctx context.Context, message string, args ...interface{}) {
+	if stub.TraceCtxCalled != nil {
+		stub.TraceCtxCalled(ctx, message, args...)
+	}
+}
+
+// DebugCtx -
+func (stub *LoggerStub) DebugCtx(ctx context.Context, message string, args ...interface{}) {
+	if stub.DebugCtxCalled != nil {
+		stub.DebugCtxCalled(ctx, message, args...)
+	}
+}
+
+// InfoCtx -
+func (stub *LoggerStub) InfoCtx(ctx context.Context, message string, args ...interface{}) {
+	if stub.InfoCtxCalled != nil {
+		stub.InfoCtxCalled(ctx, message, args...)
+	}
+}
+
+// WarnCtx -
+func (stub *LoggerStub) WarnCtx(ctx context.Context, message string, args ...interface{}) {
+	if stub.WarnCtxCalled != nil {
+		stub.WarnCtxCalled(ctx, message, args...)
+	}
+}
+
+// ErrorCtx -
+func (stub *LoggerStub) ErrorCtx(ctx context.Context, message string, args ...interface{}) {
+	if stub.ErrorCtxCalled != nil {
+		stub.ErrorCtxCalled(ctx, message, args...)
+	}
+}
+
+// LogCtx -
+func (stub *LoggerStub) LogCtx(ctx context.Context, logLevel logger.LogLevel, message string, args ...interface{}) {
+	if stub.LogCtxCalled != nil {
+		stub.LogCtxCalled(ctx, logLevel, message, args...)
+	}
+}
+
+// PanicCtx -
+func (stub *LoggerStub) PanicCtx(ctx context.Context, message string, args ...interface{}) {
+	if stub.PanicCtxCalled != nil {
+		stub.PanicCtxCalled(ctx, message, args...)
+	}
+}
+
+// FatalCtx -
+func (stub *LoggerStub) FatalCtx(ctx context.Context, message string, args ...interface{}) {
+	if stub.FatalCtxCalled != nil {
+		stub.FatalCtxCalled(ctx, message, args...)
+	}
+}
+
 // SetLevel -
 func (stub *LoggerStub) SetLevel(logLevel logger.LogLevel) {
 	if stub.SetLevelCalled != nil {
@@ -88,6 +198,48 @@ func (stub *LoggerStub) GetLevel() logger.LogLevel {
 	return logger.LogTrace
 }
 
+// TraceFields -
+func (stub *LoggerStub) TraceFields(message string, fields ...logger.Field) {
+	if stub.TraceFieldsCalled != nil {
+		stub.TraceFieldsCalled(message, fields...)
+	}
+}
+
+// DebugFields -
+func (stub *LoggerStub) DebugFields(message string, fields ...logger.Field) {
+	if stub.DebugFieldsCalled != nil {
+		stub.DebugFieldsCalled(message, fields...)
+	}
+}
+
+// InfoFields -
+func (stub *LoggerStub) InfoFields(message string, fields ...logger.Field) {
+	if stub.InfoFieldsCalled != nil {
+		stub.InfoFieldsCalled(message, fields...)
+	}
+}
+
+// WarnFields -
+func (stub *LoggerStub) WarnFields(message string, fields ...logger.Field) {
+	if stub.WarnFieldsCalled != nil {
+		stub.WarnFieldsCalled(message, fields...)
+	}
+}
+
+// ErrorFields -
+func (stub *LoggerStub) ErrorFields(message string, fields ...logger.Field) {
+	if stub.ErrorFieldsCalled != nil {
+		stub.ErrorFieldsCalled(message, fields...)
+	}
+}
+
+// LogFields -
+func (stub *LoggerStub) LogFields(logLevel logger.LogLevel, message string, fields ...logger.Field) {
+	if stub.LogFieldsCalled != nil {
+		stub.LogFieldsCalled(logLevel, message, fields...)
+	}
+}
+
 // IsInterfaceNil -
 func (stub *LoggerStub) IsInterfaceNil() bool {
 	return stub == nil
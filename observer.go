@@ -0,0 +1,333 @@
+package logger
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"time"
+
+	"github.com/Dharitri-org/me-core/core/check"
+)
+
+// maxPooledBufferSize is the maximum capacity of a formatting buffer that is put back in its pool
+const maxPooledBufferSize = 64 * 1024
+
+// releasePollInterval is the interval at which a removed observer checks if its in-flight outputs ended, after
+// yielding the processor for numReleaseYields times, as the in-flight outputs usually end soon
+const releasePollInterval = 100 * time.Microsecond
+const numReleaseYields = 100
+
+var bufferPool = sync.Pool{
+	New: func() interface{} {
+		buff := make([]byte, 0, 512)
+		return &buff
+	},
+}
+
+// syncer is a writer able to commit its output to the stable storage, such as a file
+type syncer interface {
+	Sync() error
+}
+
+// ObserverOptions holds the optional settings of an observer (writer + formatter).
+// The named observers can be referred by the profiles. The CloseOnRemove option is taken into account only when
+// the observer is added: if set, the writer is closed, if it is an io.Closer, when the observer is removed.
+type ObserverOptions struct {
+	Name              string
+	MinLevel          LogLevel
+	LoggerNamePattern string
+	CloseOnRemove     bool `json:"-"`
+}
+
+// ObserverID identifies an observer added on a log output subject. The IDs are never reused by a subject, so the same
+// writer can be added more times, with different formatters, and each observer can be removed or replaced by its ID
+type ObserverID uint64
+
+// ObserverInfo describes an observer added on a log output subject
+type ObserverInfo struct {
+	ID            ObserverID
+	Name          string
+	FormatterType string
+	Health        ObserverHealth
+}
+
+// observer holds a writer + formatter pair, or a LogLineObserver, along with the filter that decides which log lines
+// reach them. If the formatter is a BufferedFormatter, the output is appended on pooled buffers. If the formatter or
+// the LogLineObserver does not belong to this package, it receives copies of the pooled log lines, so it can
+// retain them.
+// The observer is used without locking: its settings are an immutable observerConfig swapped atomically, and
+// its in-flight outputs are counted, so the release can wait for them. In the fan-out mode, the observer has its own
+// queue, also swapped atomically
+type observer struct {
+	id                ObserverID
+	writer            io.Writer
+	formatter         Formatter
+	bufferedFormatter BufferedFormatter
+	lineObserver      LogLineObserver
+	clonesLines       bool
+	closeOnRemove     bool
+	config            atomic.Value
+	queue             atomic.Value
+	health            observerHealth
+	numInFlight       int64
+	isReleased        uint32
+}
+
+// observerConfig holds the settings of an observer that can be changed after adding it. A config is never changed
+// once stored, the changes being done on copies
+type observerConfig struct {
+	options   ObserverOptions
+	matcher   *loggerNameMatcher
+	policy    WriteErrorPolicy
+	queueArgs *ArgsAsyncOutput
+}
+
+func newObserver(w io.Writer, format Formatter, options ObserverOptions) (*observer, error) {
+	if w == nil {
+		return nil, ErrNilWriter
+	}
+	if check.IfNil(format) {
+		return nil, ErrNilFormatter
+	}
+
+	obs := &observer{
+		writer:        w,
+		formatter:     format,
+		closeOnRemove: options.CloseOnRemove,
+	}
+	obs.bufferedFormatter, _ = format.(BufferedFormatter)
+	obs.clonesLines = !isPackageFormatter(format)
+	obs.config.Store(&observerConfig{})
+
+	err := obs.setOptions(options)
+	if err != nil {
+		return nil, err
+	}
+
+	return obs, nil
+}
+
+func newLineObserver(o LogLineObserver, options ObserverOptions) (*observer, error) {
+	if check.IfNil(o) {
+		return nil, ErrNilLogLineObserver
+	}
+
+	obs := &observer{
+		lineObserver:  o,
+		closeOnRemove: options.CloseOnRemove,
+	}
+	obs.clonesLines = !isPackageLineObserver(o)
+	obs.config.Store(&observerConfig{})
+
+	err := obs.setOptions(options)
+	if err != nil {
+		return nil, err
+	}
+
+	return obs, nil
+}
+
+// isPackageLineObserver returns true if the LogLineObserver belongs to this package, so it copies the log lines it
+// retains
+func isPackageLineObserver(o LogLineObserver) bool {
+	switch o.(type) {
+	case *DedupObserver, *RingBufferObserver, *FingersCrossedObserver:
+		return true
+	default:
+		return false
+	}
+}
+
+// isPackageFormatter returns true if the formatter belongs to this package, so it does not retain the log lines
+func isPackageFormatter(format Formatter) bool {
+	switch format.(type) {
+	case *PlainFormatter, *ConsoleFormatter, *JSONFormatter, *LogfmtFormatter, *PatternFormatter,
+		*logLineWrapperFormatter:
+		return true
+	default:
+		return false
+	}
+}
+
+// formatLogLine returns the output of the formatter for the provided log line. The formatters not belonging to this
+// package receive a copy of the log line, as they might retain it
+func formatLogLine(format Formatter, line LogLineHandler) []byte {
+	if !isPackageFormatter(format) {
+		return format.Output(CloneLogLine(line))
+	}
+
+	return format.Output(line)
+}
+
+func (obs *observer) loadConfig() *observerConfig {
+	return obs.config.Load().(*observerConfig)
+}
+
+// setOptions stores a config holding the provided options. The config changes should be serialized by the caller
+func (obs *observer) setOptions(options ObserverOptions) error {
+	matcher, err := newLoggerNameMatcher(options.LoggerNamePattern)
+	if err != nil {
+		return err
+	}
+
+	options.CloseOnRemove = obs.closeOnRemove
+	config := *obs.loadConfig()
+	config.options = options
+	config.matcher = matcher
+	obs.config.Store(&config)
+
+	return nil
+}
+
+// setPolicy stores a config holding the provided write error policy and resets the failures state, enabling back
+// a disabled observer. The config changes should be serialized by the caller
+func (obs *observer) setPolicy(policy WriteErrorPolicy) {
+	config := *obs.loadConfig()
+	config.policy = policy
+	obs.config.Store(&config)
+
+	obs.health.resetFailures()
+}
+
+// target returns the component receiving the log lines: the LogLineObserver, if set, otherwise the writer
+func (obs *observer) target() interface{} {
+	if obs.lineObserver != nil {
+		return obs.lineObserver
+	}
+
+	return obs.writer
+}
+
+func (obs *observer) getOptions() ObserverOptions {
+	return obs.loadConfig().options
+}
+
+func (obs *observer) getInfo() ObserverInfo {
+	return ObserverInfo{
+		ID:            obs.id,
+		Name:          obs.getOptions().Name,
+		FormatterType: obs.formatterType(),
+		Health:        obs.getHealth(),
+	}
+}
+
+// formatterType returns the type of the formatter or, for the LogLineObserver, the type of the LogLineObserver,
+// as it formats the log lines itself
+func (obs *observer) formatterType() string {
+	if obs.lineObserver != nil {
+		return fmt.Sprintf("%T", obs.lineObserver)
+	}
+
+	return fmt.Sprintf("%T", obs.formatter)
+}
+
+// isAccepting returns true if the observer was not disabled by its write error policy and the log line passes the
+// minimum level and the logger name pattern of the observer
+func (obs *observer) isAccepting(config *observerConfig, line LogLineHandler) bool {
+	if obs.health.disabled() {
+		return false
+	}
+	if check.IfNil(line) {
+		return true
+	}
+	if LogLevel(line.GetLogLevel()) < config.options.MinLevel {
+		return false
+	}
+
+	return config.matcher.isMatching(line.GetLoggerName())
+}
+
+// output formats and writes the log line if the observer accepts it. A released observer ignores the log line,
+// as its writer might be closed
+func (obs *observer) output(line LogLineHandler) {
+	atomic.AddInt64(&obs.numInFlight, 1)
+	defer atomic.AddInt64(&obs.numInFlight, -1)
+
+	if atomic.LoadUint32(&obs.isReleased) == 1 {
+		return
+	}
+
+	config := obs.loadConfig()
+	if !obs.isAccepting(config, line) {
+		return
+	}
+	if obs.clonesLines && !check.IfNil(line) {
+		line = CloneLogLine(line)
+	}
+	if obs.lineObserver != nil {
+		obs.outputLogLine(config, line)
+		return
+	}
+
+	if obs.bufferedFormatter == nil {
+		obs.write(config, obs.formatter.Output(line))
+		return
+	}
+
+	pooledBuff := bufferPool.Get().(*[]byte)
+	buff := obs.bufferedFormatter.AppendOutput((*pooledBuff)[:0], line)
+	obs.write(config, buff)
+
+	if cap(buff) > maxPooledBufferSize {
+		bufferPool.Put(pooledBuff)
+		return
+	}
+	*pooledBuff = buff
+	bufferPool.Put(pooledBuff)
+}
+
+// flush flushes the writer, or the LogLineObserver, if it has a Flush() error method or syncs it if it has a
+// Sync() error method
+func (obs *observer) flush() error {
+	switch writer := obs.target().(type) {
+	case Flusher:
+		return writer.Flush()
+	case syncer:
+		err := writer.Sync()
+		if errors.Is(err, syscall.EINVAL) {
+			// the standard output streams can not be synced when they are terminals or pipes
+			return nil
+		}
+
+		return err
+	default:
+		return nil
+	}
+}
+
+// release writes the log lines queued on the own queue of a removed observer and waits for its in-flight outputs to
+// end, then flushes its writer and closes it if the observer was added with the CloseOnRemove option.
+// The flush error is returned, if any, otherwise the close error
+func (obs *observer) release() error {
+	obs.stopQueue()
+	atomic.StoreUint32(&obs.isReleased, 1)
+	for i := 0; atomic.LoadInt64(&obs.numInFlight) > 0; i++ {
+		if i < numReleaseYields {
+			runtime.Gosched()
+			continue
+		}
+
+		time.Sleep(releasePollInterval)
+	}
+
+	err := obs.flush()
+	if !obs.closeOnRemove {
+		return err
+	}
+
+	closer, ok := obs.target().(io.Closer)
+	if !ok {
+		return err
+	}
+
+	errClose := closer.Close()
+	if err != nil {
+		return err
+	}
+
+	return errClose
+}
@@ -0,0 +1,240 @@
+package logger
+
+import (
+	"fmt"
+	"io"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// maxRetryBackoff caps the time waited before a write retry, as the retries block the log calls in the synchronous mode
+const maxRetryBackoff = 100 * time.Millisecond
+
+// WriteErrorPolicy defines how an observer reacts when its writer fails. The policies can be combined: a failed
+// write is first retried, then, if it still fails, the error handler is called and the log line is written on the
+// fallback writer. The zero value keeps the default behavior: the errors are only counted
+type WriteErrorPolicy struct {
+	// MaxConsecutiveFailures disables the observer after the provided number of consecutive failed writes,
+	// 0 meaning never
+	MaxConsecutiveFailures uint64
+	// MaxRetries is the number of times a failed write is retried
+	MaxRetries int
+	// RetryBackoff is the time waited before the first retry, doubled before each of the next ones up to
+	// maxRetryBackoff. The retries block the log calls in the synchronous mode
+	RetryBackoff time.Duration
+	// FallbackWriter receives the log lines that could not be written, for example os.Stderr
+	FallbackWriter io.Writer
+	// ErrorHandler is called with the observer name and the write error for each failed write
+	ErrorHandler func(observerName string, err error)
+}
+
+// ObserverHealth holds the write statistics of an observer. For the observers added with RegisterLogLineObserver,
+// the writes are not counted, as the LogLineObserver does them, while its errors are counted as failures.
+// The queue statistics are set in the fan-out mode: the dropped log lines and the queue length are the ones of the
+// observer's current queue, while the latencies, measured between the queuing and the writing of the log lines,
+// are the ones of all the observer's queues
+type ObserverHealth struct {
+	Name                string
+	WriterType          string
+	NumWrites           uint64
+	NumFailures         uint64
+	ConsecutiveFailures uint64
+	NumFallbackWrites   uint64
+	LastError           string
+	LastErrorTime       time.Time
+	IsDisabled          bool
+	QueueLength         int
+	NumDroppedLines     uint64
+	AverageQueueLatency time.Duration
+	MaxQueueLatency     time.Duration
+}
+
+// observerHealth holds the write statistics of an observer. The counters changed on each write are accessed
+// atomically, the failures state being changed under mutFailures
+type observerHealth struct {
+	numWrites           uint64
+	numQueuedWrites     uint64
+	totalQueueLatency   int64
+	maxQueueLatency     int64
+	isDisabled          uint32
+	mutFailures         sync.Mutex
+	numFailures         uint64
+	consecutiveFailures uint64
+	numFallbackWrites   uint64
+	lastError           error
+	lastErrorTime       time.Time
+}
+
+// resetFailures resets the failures state, enabling back a disabled observer
+func (health *observerHealth) resetFailures() {
+	health.mutFailures.Lock()
+	atomic.StoreUint64(&health.consecutiveFailures, 0)
+	atomic.StoreUint32(&health.isDisabled, 0)
+	health.mutFailures.Unlock()
+}
+
+func (health *observerHealth) disabled() bool {
+	return atomic.LoadUint32(&health.isDisabled) == 1
+}
+
+// write writes the provided bytes applying the write error policy of the provided config
+func (obs *observer) write(config *observerConfig, buff []byte) {
+	health := &obs.health
+	policy := config.policy
+	atomic.AddUint64(&health.numWrites, 1)
+
+	written, err := writeAll(obs.writer, buff)
+	backoff := capRetryBackoff(policy.RetryBackoff)
+	for retry := 0; err != nil && retry < policy.MaxRetries; retry++ {
+		time.Sleep(backoff)
+		backoff = capRetryBackoff(backoff * 2)
+
+		// only the bytes not written yet are retried, so a short write does not duplicate the written ones
+		var n int
+		n, err = writeAll(obs.writer, buff[written:])
+		written += n
+	}
+
+	if err == nil {
+		health.resetConsecutiveFailures()
+		return
+	}
+
+	obs.recordWriteFailure(config, err)
+	if policy.FallbackWriter == nil {
+		return
+	}
+
+	_, errFallback := writeAll(policy.FallbackWriter, buff)
+	if errFallback == nil {
+		health.mutFailures.Lock()
+		health.numFallbackWrites++
+		health.mutFailures.Unlock()
+	}
+}
+
+// outputLogLine hands the log line to the LogLineObserver. The log line is not counted as a write, as the
+// LogLineObserver decides if and when it writes it. A failure is recorded and provided to the error handler of the
+// write error policy, the retries and the fallback writer being used only for the writers, as the LogLineObserver
+// might have partially handled the log line
+func (obs *observer) outputLogLine(config *observerConfig, line LogLineHandler) {
+	err := obs.lineObserver.OutputLogLine(line)
+	if err == nil {
+		obs.health.resetConsecutiveFailures()
+		return
+	}
+
+	obs.recordWriteFailure(config, err)
+}
+
+// recordWriteFailure records the failure and calls the error handler of the write error policy, if any
+func (obs *observer) recordWriteFailure(config *observerConfig, err error) {
+	err = obs.health.recordFailure(err, config.policy.MaxConsecutiveFailures)
+	if config.policy.ErrorHandler != nil {
+		config.policy.ErrorHandler(config.options.Name, err)
+	}
+}
+
+func (health *observerHealth) resetConsecutiveFailures() {
+	if atomic.LoadUint64(&health.consecutiveFailures) == 0 {
+		return
+	}
+
+	health.mutFailures.Lock()
+	atomic.StoreUint64(&health.consecutiveFailures, 0)
+	health.mutFailures.Unlock()
+}
+
+func (health *observerHealth) recordQueueLatency(latency time.Duration) {
+	atomic.AddUint64(&health.numQueuedWrites, 1)
+	atomic.AddInt64(&health.totalQueueLatency, int64(latency))
+	for {
+		maxLatency := atomic.LoadInt64(&health.maxQueueLatency)
+		if int64(latency) <= maxLatency {
+			return
+		}
+		if atomic.CompareAndSwapInt64(&health.maxQueueLatency, maxLatency, int64(latency)) {
+			return
+		}
+	}
+}
+
+// writeAll writes the provided buffer, returning the number of written bytes along with io.ErrShortWrite if the
+// writer wrote less than the whole buffer without an error
+func writeAll(w io.Writer, buff []byte) (int, error) {
+	n, err := w.Write(buff)
+	if n < 0 || n > len(buff) {
+		n = 0
+	}
+	if err != nil {
+		return n, err
+	}
+	if n < len(buff) {
+		return n, io.ErrShortWrite
+	}
+
+	return n, nil
+}
+
+func capRetryBackoff(backoff time.Duration) time.Duration {
+	if backoff > maxRetryBackoff {
+		return maxRetryBackoff
+	}
+
+	return backoff
+}
+
+// recordFailure counts the failed write, disabling the observer if the maximum number of consecutive failures was
+// reached, 0 meaning never. The returned error signals the disabling, if it is the case
+func (health *observerHealth) recordFailure(err error, maxFailures uint64) error {
+	health.mutFailures.Lock()
+	defer health.mutFailures.Unlock()
+
+	health.numFailures++
+	consecutiveFailures := atomic.AddUint64(&health.consecutiveFailures, 1)
+	health.lastError = err
+	health.lastErrorTime = time.Now()
+
+	if maxFailures == 0 || consecutiveFailures < maxFailures || health.disabled() {
+		return err
+	}
+
+	atomic.StoreUint32(&health.isDisabled, 1)
+
+	return fmt.Errorf("%w after %d consecutive failures: %v", ErrObserverDisabled, consecutiveFailures, err)
+}
+
+func (obs *observer) getHealth() ObserverHealth {
+	health := &obs.health
+	health.mutFailures.Lock()
+	defer health.mutFailures.Unlock()
+
+	observerHealth := ObserverHealth{
+		Name:                obs.getOptions().Name,
+		WriterType:          fmt.Sprintf("%T", obs.target()),
+		NumWrites:           atomic.LoadUint64(&health.numWrites),
+		NumFailures:         health.numFailures,
+		ConsecutiveFailures: atomic.LoadUint64(&health.consecutiveFailures),
+		NumFallbackWrites:   health.numFallbackWrites,
+		LastErrorTime:       health.lastErrorTime,
+		IsDisabled:          health.disabled(),
+	}
+	if health.lastError != nil {
+		observerHealth.LastError = health.lastError.Error()
+	}
+
+	numQueuedWrites := int64(atomic.LoadUint64(&health.numQueuedWrites))
+	if numQueuedWrites > 0 {
+		totalQueueLatency := atomic.LoadInt64(&health.totalQueueLatency)
+		observerHealth.AverageQueueLatency = time.Duration(totalQueueLatency / numQueuedWrites)
+		observerHealth.MaxQueueLatency = time.Duration(atomic.LoadInt64(&health.maxQueueLatency))
+	}
+	queue := obs.loadQueue()
+	if queue != nil {
+		observerHealth.QueueLength = queue.queueLength()
+		observerHealth.NumDroppedLines = queue.droppedLines()
+	}
+
+	return observerHealth
+}
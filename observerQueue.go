@@ -0,0 +1,103 @@
+package logger
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+var queuedLogLinePool = sync.Pool{
+	New: func() interface{} {
+		return &queuedLogLine{}
+	},
+}
+
+// queuedLogLine is a converted log line shared by the observers queues in the fan-out mode. Its references are
+// counted, so the pooled log line is put back in its pool by the last observer writing it. The log lines dropped
+// by the queues are not put back in the pools
+type queuedLogLine struct {
+	LogLineHandler
+	enqueuedAt time.Time
+	numRefs    int32
+}
+
+// acquireQueuedLogLine returns a queued log line holding the provided line and a reference for the caller
+func acquireQueuedLogLine(line LogLineHandler) *queuedLogLine {
+	queued := queuedLogLinePool.Get().(*queuedLogLine)
+	queued.LogLineHandler = line
+	queued.enqueuedAt = time.Now()
+	queued.numRefs = 1
+
+	return queued
+}
+
+func (queued *queuedLogLine) retain() {
+	atomic.AddInt32(&queued.numRefs, 1)
+}
+
+func (queued *queuedLogLine) release() {
+	if atomic.AddInt32(&queued.numRefs, -1) > 0 {
+		return
+	}
+
+	releaseLogLineWrapper(queued.LogLineHandler)
+	queued.LogLineHandler = nil
+	queuedLogLinePool.Put(queued)
+}
+
+// loadQueue returns the observer's own queue, nil if the observer is not in the fan-out mode
+func (obs *observer) loadQueue() *asyncDispatcher {
+	queue, _ := obs.queue.Load().(*asyncDispatcher)
+	return queue
+}
+
+// setQueueArgs stores a config holding the provided queue arguments. The config changes should be serialized by the
+// caller
+func (obs *observer) setQueueArgs(args ArgsAsyncOutput) {
+	config := *obs.loadConfig()
+	config.queueArgs = &args
+	obs.config.Store(&config)
+}
+
+// startQueue starts the observer's own queue, using the observer's queue arguments, if set, otherwise the provided
+// ones. A previous queue is replaced, its log lines being written before the ones of the new queue. The queue
+// changes should be serialized by the caller
+func (obs *observer) startQueue(defaultArgs ArgsAsyncOutput) {
+	args := defaultArgs
+	queueArgs := obs.loadConfig().queueArgs
+	if queueArgs != nil {
+		args = *queueArgs
+	}
+
+	obs.queue.Store(newAsyncDispatcher(args, obs.writeQueued, obs.loadQueue()))
+}
+
+// stopQueue writes the log lines queued on the observer's own queue and switches the observer back from the
+// fan-out mode
+func (obs *observer) stopQueue() {
+	queue, _ := obs.queue.Swap((*asyncDispatcher)(nil)).(*asyncDispatcher)
+	if queue != nil {
+		queue.close()
+	}
+}
+
+// flushQueue waits until all the log lines queued on the observer's own queue are written
+func (obs *observer) flushQueue() {
+	queue := obs.loadQueue()
+	if queue != nil {
+		queue.flush()
+	}
+}
+
+// writeQueued writes a log line taken from the observer's own queue, recording its queue latency
+func (obs *observer) writeQueued(line LogLineHandler) {
+	queued, ok := line.(*queuedLogLine)
+	if !ok {
+		obs.output(line)
+		return
+	}
+
+	obs.health.recordQueueLatency(time.Since(queued.enqueuedAt))
+	obs.output(queued.LogLineHandler)
+	queued.release()
+}
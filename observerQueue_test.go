@@ -0,0 +1,195 @@
+package logger_test
+
+import (
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	logger "github.com/Dharitri-org/me-core-logger-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLogOutputSubject_EnableObserverQueuesInvalidArgsShouldErr(t *testing.T) {
+	t.Parallel()
+
+	los := logger.NewLogOutputSubject()
+
+	err := los.EnableObserverQueues(logger.ArgsAsyncOutput{QueueSize: 0})
+	assert.Equal(t, logger.ErrInvalidQueueSize, err)
+
+	err = los.SetObserverQueue(1, logger.ArgsAsyncOutput{QueueSize: 1, OverflowPolicy: 42})
+	assert.Equal(t, logger.ErrInvalidOverflowPolicy, err)
+
+	err = los.SetObserverQueue(1, logger.ArgsAsyncOutput{QueueSize: 1})
+	assert.Equal(t, logger.ErrObserverNotFound, err)
+
+	err = los.EnableObserverQueues(logger.ArgsAsyncOutput{QueueSize: 1})
+	assert.Nil(t, err)
+
+	err = los.EnableObserverQueues(logger.ArgsAsyncOutput{QueueSize: 1})
+	assert.Equal(t, logger.ErrObserverQueuesAlreadyEnabled, err)
+
+	los.DisableObserverQueues()
+}
+
+func TestLogOutputSubject_ObserverQueuesSlowObserverShouldNotDelayTheOthers(t *testing.T) {
+	t.Parallel()
+
+	los := logger.NewLogOutputSubject()
+	slow := newBlockingObserver(los)
+	fast := &syncBuffer{}
+	_ = los.AddObserver(fast, createMessageFormatterStub())
+	err := los.EnableObserverQueues(logger.ArgsAsyncOutput{QueueSize: 10})
+	require.Nil(t, err)
+
+	outputMessages(los, logger.LogInfo, "message 1", "message 2", "message 3")
+	queuedAt := time.Now()
+	assert.Eventually(t, func() bool {
+		return fast.String() == "message 1message 2message 3"
+	}, time.Second, time.Millisecond)
+	<-slow.chWriteStart
+	assert.Equal(t, []string{"message 1"}, slow.getMessages())
+
+	slowHealth := los.ListObservers()[0].Health
+	assert.Equal(t, 2, slowHealth.QueueLength)
+	assert.Equal(t, uint64(0), slowHealth.NumDroppedLines)
+
+	// the last 2 lines were queued before queuedAt and are written after the unblocking
+	minLatency := time.Since(queuedAt)
+	close(slow.chUnblock)
+	err = los.Flush()
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"message 1", "message 2", "message 3"}, slow.getMessages())
+
+	slowHealth = los.ListObservers()[0].Health
+	assert.Equal(t, 0, slowHealth.QueueLength)
+	assert.True(t, slowHealth.MaxQueueLatency >= minLatency)
+	assert.True(t, slowHealth.AverageQueueLatency > 0)
+	assert.True(t, slowHealth.AverageQueueLatency <= slowHealth.MaxQueueLatency)
+
+	los.DisableObserverQueues()
+	outputMessages(los, logger.LogInfo, "message 4")
+	assert.Equal(t, "message 1message 2message 3message 4", fast.String())
+}
+
+func TestLogOutputSubject_ObserverQueuesShouldApplyThePerObserverOverflowPolicy(t *testing.T) {
+	t.Parallel()
+
+	los := logger.NewLogOutputSubject()
+	slow := newBlockingObserver(los)
+	fast := &syncBuffer{}
+	_ = los.AddObserver(fast, createMessageFormatterStub())
+	slowID := los.ListObservers()[0].ID
+	err := los.SetObserverQueue(slowID, logger.ArgsAsyncOutput{QueueSize: 1, OverflowPolicy: logger.OverflowDropNewest})
+	require.Nil(t, err)
+	err = los.EnableObserverQueues(logger.ArgsAsyncOutput{QueueSize: 10})
+	require.Nil(t, err)
+
+	outputMessages(los, logger.LogInfo, "message 1")
+	<-slow.chWriteStart
+	outputMessages(los, logger.LogInfo, "message 2", "message 3", "message 4")
+
+	infoList := los.ListObservers()
+	assert.Equal(t, uint64(2), infoList[0].Health.NumDroppedLines)
+	assert.Equal(t, uint64(0), infoList[1].Health.NumDroppedLines)
+
+	close(slow.chUnblock)
+	los.DisableObserverQueues()
+	assert.Equal(t, []string{"message 1", "message 2", "log lines dropped"}, slow.getMessages())
+	assert.Equal(t, "message 1message 2message 3message 4", fast.String())
+}
+
+func TestLogOutputSubject_SetObserverQueueShouldKeepTheLogLinesOrder(t *testing.T) {
+	t.Parallel()
+
+	los := logger.NewLogOutputSubject()
+	slow := newBlockingObserver(los)
+	err := los.EnableObserverQueues(logger.ArgsAsyncOutput{QueueSize: 10})
+	require.Nil(t, err)
+
+	outputMessages(los, logger.LogInfo, "message 1", "message 2")
+	<-slow.chWriteStart
+	err = los.SetObserverQueue(los.ListObservers()[0].ID, logger.ArgsAsyncOutput{QueueSize: 5})
+	require.Nil(t, err)
+	outputMessages(los, logger.LogInfo, "message 3", "message 4")
+
+	close(slow.chUnblock)
+	err = los.Flush()
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"message 1", "message 2", "message 3", "message 4"}, slow.getMessages())
+	los.DisableObserverQueues()
+}
+
+func TestLogOutputSubject_CloseShouldDrainTheObserverQueues(t *testing.T) {
+	t.Parallel()
+
+	operations := make([]string, 0)
+	mutOperations := sync.Mutex{}
+	record := func(operation string) {
+		mutOperations.Lock()
+		operations = append(operations, operation)
+		mutOperations.Unlock()
+	}
+	los := logger.NewLogOutputSubject()
+	for _, name := range []string{"console", "file"} {
+		writerName := name
+		writer := createClosingWriterStub(nil, writerName)
+		writer.WriteCalled = func(p []byte) (n int, err error) {
+			record(string(p))
+			return len(p), nil
+		}
+		writer.FlushCalled = func() error {
+			record(writerName + " flush")
+			return nil
+		}
+		writer.CloseCalled = func() error {
+			record(writerName + " close")
+			return nil
+		}
+		_ = los.AddObserverWithOptions(writer, createMessageFormatterStub(), logger.ObserverOptions{CloseOnRemove: true})
+	}
+	err := los.EnableAsync(logger.ArgsAsyncOutput{QueueSize: 10})
+	require.Nil(t, err)
+	err = los.EnableObserverQueues(logger.ArgsAsyncOutput{QueueSize: 10})
+	require.Nil(t, err)
+
+	outputMessages(los, logger.LogInfo, "message 1", "message 2")
+	err = los.Close()
+	assert.Nil(t, err)
+
+	mutOperations.Lock()
+	defer mutOperations.Unlock()
+	joined := strings.Join(operations, ",")
+	assert.True(t, strings.Contains(joined, "message 1,message 2,console flush,console close"), joined)
+	assert.True(t, strings.Contains(joined, "file flush,file close"), joined)
+	assert.Equal(t, 8, len(operations))
+}
+
+func TestLogOutputSubject_ObserverQueuesShouldWriteTheSameLogLinesOnAllTheObservers(t *testing.T) {
+	t.Parallel()
+
+	los := logger.NewLogOutputSubject()
+	buffers := make([]*syncBuffer, 0)
+	for i := 0; i < 3; i++ {
+		buffer := &syncBuffer{}
+		buffers = append(buffers, buffer)
+		_ = los.AddObserver(buffer, &logger.PlainFormatter{})
+	}
+	err := los.EnableObserverQueues(logger.ArgsAsyncOutput{QueueSize: 1000})
+	require.Nil(t, err)
+
+	numLines := 100
+	for i := 0; i < numLines; i++ {
+		los.Output(&logger.LogLine{Message: "message", LogLevel: logger.LogInfo, Args: []interface{}{"index", i}})
+	}
+	los.DisableObserverQueues()
+
+	output := buffers[0].String()
+	assert.Equal(t, numLines, strings.Count(output, "\n"))
+	assert.Contains(t, output, "index = 99")
+	for _, buffer := range buffers[1:] {
+		assert.Equal(t, output, buffer.String())
+	}
+}
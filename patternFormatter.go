@@ -0,0 +1,465 @@
+package logger
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	patternElementPrefix   = '%'
+	patternSpecStart       = '{'
+	patternSpecEnd         = '}'
+	patternTokensSeparator = "|"
+	patternDefaultTime     = "2006-01-02 15:04:05.000"
+	patternTokenBrackets   = "brackets"
+	patternTokenColor      = "color"
+	patternTokenUTC        = "utc"
+	patternTokenLocal      = "local"
+	patternTokenSpace      = "space"
+	ansiEscape             = "\033["
+	ansiReset              = "\033[0m"
+)
+
+var patternWidthRegex = regexp.MustCompile(`^(-?)(\d*)(?:\.(-?)(\d+))?$`)
+
+var patternNamedColors = map[string]string{
+	"gray":      ansiRegularGray,
+	"lightblue": ansiRegularLightBlue,
+	"green":     ansiRegularGreen,
+	"yellow":    ansiRegularYellow,
+	"red":       ansiRegularRed,
+	"black":     ansiRegularBlack,
+	"magenta":   ansiRegularMagenta,
+}
+
+type patternElementKind byte
+
+const (
+	patternLiteral patternElementKind = iota
+	patternLevel
+	patternTime
+	patternLogger
+	patternCorrelation
+	patternMessage
+	patternArgs
+	patternCaller
+	patternFunction
+	patternStackTrace
+)
+
+var patternElementKinds = map[string]patternElementKind{
+	"level":  patternLevel,
+	"time":   patternTime,
+	"logger": patternLogger,
+	"corr":   patternCorrelation,
+	"msg":    patternMessage,
+	"args":   patternArgs,
+	"caller": patternCaller,
+	"func":   patternFunction,
+	"stack":  patternStackTrace,
+}
+
+// ArgsPatternFormatter is the argument used to create a new PatternFormatter
+type ArgsPatternFormatter struct {
+	Template    string
+	LoggerName  FieldVisibility
+	Correlation FieldVisibility
+}
+
+// PatternFormatter implements formatter interface and is used to format log lines in the form described by a
+// template. The template text is written as it is, except for the elements that start with '%':
+//   - %level the log level name (TRACE, DEBUG, INFO, WARN, ERROR...)
+//   - %time{layout} the log line timestamp in the provided time layout, the default one being
+//     "2006-01-02 15:04:05.000"
+//   - %logger the logger name
+//   - %corr the correlation elements, as shard/epoch/round/subround
+//   - %msg the message
+//   - %args the arguments, as "name1 = value1 name2 = value2 " (an odd argument is ignored)
+//   - %caller the caller location, as file:line (see ToggleCaller and SetCallerPathDisplay)
+//   - %func the caller function
+//   - %stack the attached stack trace, one frame on two tab-indented lines (see SetStackTracePattern)
+//   - %% the '%' character
+//
+// Each element can have a spec between braces containing tokens separated by '|' (for %time, the first token is
+// always the layout). The tokens are:
+//   - a width in the [-]W[.[-]M] form: the value is padded with spaces up to W bytes, on the left (right aligned)
+//     or, if W is prefixed by '-', on the right (left aligned). A value longer than M bytes is truncated, keeping
+//     its end and prefixed by ".." or, if M is prefixed by '-', keeping its beginning and suffixed by ".."
+//   - brackets: the (truncated) value is enclosed in square brackets before padding
+//   - color: the (padded) value is colored with the ANSI color of the log level. For %args, only the arguments
+//     names are colored. A fixed color can be chosen by its name: gray, lightblue, green, yellow, red, black or
+//     magenta
+//   - utc or local (only for %time): the time zone in which the timestamp is written, the default being local
+//   - space: a space is written after the element, only if the element is written
+//
+// The %logger and %corr elements follow the global toggles unless overridden by the LoggerName and Correlation
+// options, while the %caller and %func elements are written only for the log lines having a caller location and
+// the %stack element only for the log lines having a stack trace.
+// A hidden element is not written at all (no padding) while the %level of an unknown log level is written
+// as an empty, not padded, value.
+// Example: "%time{15:04:05.000|utc} %level{-5|color} %logger{brackets|30} %corr %msg{-50} %args\n"
+type PatternFormatter struct {
+	elements    []patternElement
+	loggerName  FieldVisibility
+	correlation FieldVisibility
+}
+
+type patternElement struct {
+	kind          patternElementKind
+	literal       string
+	timeLayout    string
+	useUTC        bool
+	minWidth      int
+	padRight      bool
+	maxWidth      int
+	keepBeginning bool
+	withBrackets  bool
+	withColor     bool
+	color         string
+	withSpace     bool
+}
+
+// NewPatternFormatter creates a new PatternFormatter from the provided template
+func NewPatternFormatter(args ArgsPatternFormatter) (*PatternFormatter, error) {
+	elements, err := parsePatternTemplate(args.Template)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidPatternTemplate, err.Error())
+	}
+
+	return &PatternFormatter{
+		elements:    elements,
+		loggerName:  args.LoggerName,
+		correlation: args.Correlation,
+	}, nil
+}
+
+// newPresetPatternFormatter creates the PatternFormatter used by a preset formatter. The preset templates are
+// constants, so an error here can only be a programming error
+func newPresetPatternFormatter(template string) *PatternFormatter {
+	pf, err := NewPatternFormatter(ArgsPatternFormatter{Template: template})
+	if err != nil {
+		panic(err)
+	}
+
+	return pf
+}
+
+func parsePatternTemplate(template string) ([]patternElement, error) {
+	elements := make([]patternElement, 0)
+	literal := strings.Builder{}
+	for len(template) > 0 {
+		elementStart := strings.IndexByte(template, patternElementPrefix)
+		if elementStart < 0 {
+			literal.WriteString(template)
+			break
+		}
+		literal.WriteString(template[:elementStart])
+		template = template[elementStart+1:]
+
+		if len(template) > 0 && template[0] == patternElementPrefix {
+			literal.WriteByte(patternElementPrefix)
+			template = template[1:]
+			continue
+		}
+
+		nameEnd := 0
+		for nameEnd < len(template) && template[nameEnd] >= 'a' && template[nameEnd] <= 'z' {
+			nameEnd++
+		}
+		name := template[:nameEnd]
+		template = template[nameEnd:]
+		kind, found := patternElementKinds[name]
+		if !found {
+			return nil, fmt.Errorf("unknown element '%%%s'", name)
+		}
+
+		spec := ""
+		if len(template) > 0 && template[0] == patternSpecStart {
+			specEnd := strings.IndexByte(template, patternSpecEnd)
+			if specEnd < 0 {
+				return nil, fmt.Errorf("unterminated spec for element '%%%s'", name)
+			}
+			spec = template[1:specEnd]
+			template = template[specEnd+1:]
+		}
+
+		element, err := newPatternElement(kind, spec)
+		if err != nil {
+			return nil, fmt.Errorf("element '%%%s': %w", name, err)
+		}
+
+		if literal.Len() > 0 {
+			elements = append(elements, patternElement{kind: patternLiteral, literal: literal.String()})
+			literal.Reset()
+		}
+		elements = append(elements, element)
+	}
+
+	if literal.Len() > 0 {
+		elements = append(elements, patternElement{kind: patternLiteral, literal: literal.String()})
+	}
+
+	return elements, nil
+}
+
+func newPatternElement(kind patternElementKind, spec string) (patternElement, error) {
+	element := patternElement{
+		kind:       kind,
+		timeLayout: patternDefaultTime,
+	}
+	if len(spec) == 0 {
+		return element, nil
+	}
+
+	tokens := strings.Split(spec, patternTokensSeparator)
+	if kind == patternTime {
+		if len(tokens[0]) > 0 {
+			element.timeLayout = tokens[0]
+		}
+		tokens = tokens[1:]
+	}
+
+	for _, token := range tokens {
+		err := element.applyToken(token)
+		if err != nil {
+			return patternElement{}, err
+		}
+	}
+
+	return element, nil
+}
+
+func (pe *patternElement) applyToken(token string) error {
+	if pe.kind == patternTime && (token == patternTokenUTC || token == patternTokenLocal) {
+		pe.useUTC = token == patternTokenUTC
+		return nil
+	}
+	if token == patternTokenBrackets {
+		pe.withBrackets = true
+		return nil
+	}
+	if token == patternTokenSpace {
+		pe.withSpace = true
+		return nil
+	}
+	if token == patternTokenColor {
+		pe.withColor = true
+		return nil
+	}
+	color, found := patternNamedColors[token]
+	if found {
+		pe.withColor = true
+		pe.color = color
+		return nil
+	}
+
+	matches := patternWidthRegex.FindStringSubmatch(token)
+	if matches == nil || len(matches[2])+len(matches[4]) == 0 {
+		return fmt.Errorf("unknown token '%s'", token)
+	}
+
+	pe.padRight = len(matches[1]) > 0
+	pe.minWidth, _ = strconv.Atoi(matches[2])
+	pe.keepBeginning = len(matches[3]) > 0
+	pe.maxWidth, _ = strconv.Atoi(matches[4])
+
+	return nil
+}
+
+// Output converts the provided LogLineHandler into a slice of bytes ready for output
+func (pf *PatternFormatter) Output(line LogLineHandler) []byte {
+	if line == nil {
+		return nil
+	}
+
+	return pf.AppendOutput(make([]byte, 0, 256), line)
+}
+
+// AppendOutput appends the formatted LogLineHandler to the provided buffer and returns the extended buffer
+func (pf *PatternFormatter) AppendOutput(buff []byte, line LogLineHandler) []byte {
+	if line == nil {
+		return buff
+	}
+
+	level := LogLevel(line.GetLogLevel())
+	levelColor := getLevelColor(level)
+	for i := range pf.elements {
+		buff = pf.elements[i].appendTo(buff, pf, line, level, levelColor)
+	}
+
+	return buff
+}
+
+func (pe *patternElement) appendTo(buff []byte, pf *PatternFormatter, line LogLineHandler, level LogLevel, levelColor string) []byte {
+	if pe.kind == patternLiteral {
+		return append(buff, pe.literal...)
+	}
+	if !pe.isShown(pf, line) {
+		return buff
+	}
+
+	buff = pe.appendValue(buff, line, level, levelColor)
+	if pe.withSpace {
+		buff = append(buff, ASCIISpace)
+	}
+
+	return buff
+}
+
+func (pe *patternElement) isShown(pf *PatternFormatter, line LogLineHandler) bool {
+	switch pe.kind {
+	case patternLogger:
+		return pf.loggerName.isShown(IsEnabledLoggerName)
+	case patternCorrelation:
+		return pf.correlation.isShown(IsEnabledCorrelation)
+	case patternCaller, patternFunction:
+		return hasCaller(getCaller(line))
+	case patternStackTrace:
+		return len(getStackTrace(line)) > 0
+	default:
+		return true
+	}
+}
+
+func (pe *patternElement) appendValue(buff []byte, line LogLineHandler, level LogLevel, levelColor string) []byte {
+	color := levelColor
+	if len(pe.color) > 0 {
+		color = pe.color
+	}
+	if pe.kind == patternArgs {
+		start := len(buff)
+		if pe.withColor {
+			buff = appendArgs(buff, color, line.GetArgs())
+		} else {
+			buff = appendArgs(buff, "", line.GetArgs())
+		}
+		return pe.formatInPlace(buff, start)
+	}
+
+	if pe.withColor {
+		buff = append(buff, ansiEscape...)
+		buff = append(buff, color...)
+	}
+
+	start := len(buff)
+	switch pe.kind {
+	case patternLevel:
+		buff = append(buff, strings.TrimSpace(level.String())...)
+	case patternTime:
+		timestamp := time.Unix(0, line.GetTimestamp())
+		if pe.useUTC {
+			timestamp = timestamp.UTC()
+		}
+		buff = timestamp.AppendFormat(buff, pe.timeLayout)
+	case patternLogger:
+		buff = append(buff, line.GetLoggerName()...)
+	case patternCorrelation:
+		correlation := line.GetCorrelation()
+		buff = append(buff, correlation.GetShard()...)
+		buff = append(buff, '/')
+		buff = strconv.AppendUint(buff, uint64(correlation.GetEpoch()), 10)
+		buff = append(buff, '/')
+		buff = strconv.AppendInt(buff, correlation.GetRound(), 10)
+		buff = append(buff, '/')
+		buff = append(buff, correlation.GetSubRound()...)
+	case patternMessage:
+		buff = append(buff, line.GetMessage()...)
+	case patternCaller:
+		buff = appendCallerLocation(buff, getCaller(line))
+	case patternFunction:
+		buff = append(buff, callerFunction(getCaller(line))...)
+	case patternStackTrace:
+		buff = append(buff, getStackTrace(line)...)
+	}
+
+	// the %level of an unknown log level is not padded
+	if pe.kind != patternLevel || len(buff) > start {
+		buff = pe.formatInPlace(buff, start)
+	}
+	if pe.withColor {
+		buff = append(buff, ansiReset...)
+	}
+
+	return buff
+}
+
+// appendArgs appends the arguments in the "name1 = value1 name2 = value2 " form, ignoring an odd argument.
+// If a color is provided, the arguments names are colored
+func appendArgs(buff []byte, color string, args []string) []byte {
+	for index := 1; index < len(args); index += 2 {
+		if len(color) > 0 {
+			buff = append(buff, ansiEscape...)
+			buff = append(buff, color...)
+			buff = append(buff, args[index-1]...)
+			buff = append(buff, ansiReset...)
+		} else {
+			buff = append(buff, args[index-1]...)
+		}
+		buff = append(buff, " = "...)
+		buff = append(buff, args[index]...)
+		buff = append(buff, ASCIISpace)
+	}
+
+	return buff
+}
+
+// formatInPlace applies the truncation, the brackets and the padding on the value found in buff[start:]
+func (pe *patternElement) formatInPlace(buff []byte, start int) []byte {
+	length := len(buff) - start
+	if pe.maxWidth > 0 && length > pe.maxWidth {
+		buff = truncateInPlace(buff, start, pe.maxWidth, pe.keepBeginning)
+	}
+	if pe.withBrackets {
+		buff = append(buff, 0)
+		copy(buff[start+1:], buff[start:len(buff)-1])
+		buff[start] = '['
+		buff = append(buff, ']')
+	}
+
+	length = len(buff) - start
+	if length >= pe.minWidth {
+		return buff
+	}
+
+	padding := pe.minWidth - length
+	for i := 0; i < padding; i++ {
+		buff = append(buff, ASCIISpace)
+	}
+	if pe.padRight {
+		return buff
+	}
+
+	copy(buff[start+padding:], buff[start:start+length])
+	for i := start; i < start+padding; i++ {
+		buff[i] = ASCIISpace
+	}
+
+	return buff
+}
+
+func truncateInPlace(buff []byte, start int, maxLength int, keepBeginning bool) []byte {
+	length := len(buff) - start
+	if maxLength <= len(ellipsisString) {
+		if !keepBeginning {
+			copy(buff[start:], buff[len(buff)-maxLength:])
+		}
+		return buff[:start+maxLength]
+	}
+	if keepBeginning {
+		return append(buff[:start+maxLength-len(ellipsisString)], ellipsisString...)
+	}
+
+	kept := maxLength - len(ellipsisString)
+	copy(buff[start+len(ellipsisString):], buff[start+length-kept:])
+	copy(buff[start:], ellipsisString)
+
+	return buff[:start+maxLength]
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (pf *PatternFormatter) IsInterfaceNil() bool {
+	return pf == nil
+}
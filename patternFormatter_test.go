@@ -0,0 +1,180 @@
+package logger_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	logger "github.com/Dharitri-org/me-core-logger-go"
+	"github.com/Dharitri-org/me-core-logger-go/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createPatternTestLogLine(loggerName string, message string, level logger.LogLevel) *logger.LogLineWrapper {
+	return &logger.LogLineWrapper{
+		LogLineMessage: proto.LogLineMessage{
+			LoggerName: loggerName,
+			Correlation: proto.LogCorrelationMessage{
+				Shard:    "1",
+				Epoch:    2,
+				Round:    300,
+				SubRound: "(END)",
+			},
+			Message:   message,
+			LogLevel:  int32(level),
+			Args:      []string{"k", "v", "key2", "value 2", "odd"},
+			Timestamp: time.Date(2023, 4, 5, 6, 7, 8, 9000000, time.UTC).UnixNano(),
+		},
+	}
+}
+
+func createPatternFormatter(t *testing.T, template string) *logger.PatternFormatter {
+	pf, err := logger.NewPatternFormatter(logger.ArgsPatternFormatter{
+		Template:    template,
+		LoggerName:  logger.FieldShown,
+		Correlation: logger.FieldShown,
+	})
+	require.Nil(t, err)
+
+	return pf
+}
+
+func TestNewPatternFormatter_InvalidTemplatesShouldError(t *testing.T) {
+	t.Parallel()
+
+	invalidTemplates := []string{
+		"%unknown",
+		"%",
+		"%msg{-40",
+		"%msg{40x}",
+		"%msg{-}",
+		"%msg{utc}",
+		"%level{purple}",
+	}
+
+	for _, template := range invalidTemplates {
+		pf, err := logger.NewPatternFormatter(logger.ArgsPatternFormatter{Template: template})
+		assert.Nil(t, pf, template)
+		assert.True(t, errors.Is(err, logger.ErrInvalidPatternTemplate), template)
+	}
+}
+
+func TestPatternFormatter_OutputNilLineShouldReturnNil(t *testing.T) {
+	t.Parallel()
+
+	pf := createPatternFormatter(t, "%msg")
+
+	assert.False(t, pf.IsInterfaceNil())
+	assert.Nil(t, pf.Output(nil))
+}
+
+func TestPatternFormatter_OutputElements(t *testing.T) {
+	t.Parallel()
+
+	line := createPatternTestLogLine("process/sync", "message", logger.LogWarning)
+	testData := map[string]string{
+		"plain text 100%%":                  "plain text 100%",
+		"%time{15:04:05.000|utc}":           "06:07:08.009",
+		"%time{|utc}":                       "2023-04-05 06:07:08.009",
+		"%level %logger %corr %msg":         "WARN process/sync 1/2/300/(END) message",
+		"[%level{5}][%level{-5}]":           "[ WARN][WARN ]",
+		"%msg{10}|%msg{-10}|":               "   message|message   |",
+		"%logger{.7}|%logger{.-7}|%msg{.2}": "../sync|proce..|ge",
+		"%logger{brackets|-16.10}|":         "[..ess/sync]    |",
+		"%args":                             "k = v key2 = value 2 ",
+		"%level{color}":                     "\033[0;33mWARN\033[0m",
+		"%msg{red|-8}|":                     "\033[0;31mmessage \033[0m|",
+		"%args{green}":                      "\033[0;32mk\033[0m = v \033[0;32mkey2\033[0m = value 2 ",
+		"%level{green|color}":               "\033[0;32mWARN\033[0m",
+		"%level{color|green}":               "\033[0;32mWARN\033[0m",
+		"%corr{brackets|-20}%msg":           "[1/2/300/(END)]     message",
+		"%level{-5}%level{-5}":              "WARN WARN ",
+		"%time{2006}":                       "2023",
+		"%msg%msg{}":                        "messagemessage",
+		"%logger{brackets|.-3}%logger{.1}":  "[p..]c",
+		"%time{15h|local}":                  time.Unix(0, line.Timestamp).Format("15h"),
+		"%msg{0.0}":                         "message",
+		"unknown level [%level{-5}]":        "unknown level [WARN ]",
+	}
+
+	for template, expected := range testData {
+		pf := createPatternFormatter(t, template)
+		assert.Equal(t, expected, string(pf.Output(line)), template)
+	}
+}
+
+func TestPatternFormatter_OutputUnknownLevelShouldNotPad(t *testing.T) {
+	t.Parallel()
+
+	pf := createPatternFormatter(t, "[%level{-5}]")
+
+	assert.Equal(t, "[]", string(pf.Output(createPatternTestLogLine("", "", logger.LogLevel(100)))))
+}
+
+func TestPatternFormatter_OutputHiddenElements(t *testing.T) {
+	t.Parallel()
+
+	pf, err := logger.NewPatternFormatter(logger.ArgsPatternFormatter{
+		Template:    "%logger{brackets|-20}|%corr{brackets|-14}|%msg",
+		LoggerName:  logger.FieldHidden,
+		Correlation: logger.FieldHidden,
+	})
+	require.Nil(t, err)
+
+	assert.Equal(t, "||message", string(pf.Output(createPatternTestLogLine("main", "message", logger.LogInfo))))
+}
+
+func legacyPlainOutput(line logger.LogLineHandler) string {
+	correlation := line.GetCorrelation()
+	return fmt.Sprintf("%s[%s] %-20s %-14s %-40s %s\n",
+		logger.LogLevel(line.GetLogLevel()),
+		time.Unix(0, line.GetTimestamp()).Format("2006-01-02 15:04:05.000"),
+		"["+line.GetLoggerName()+"]",
+		fmt.Sprintf("[%s/%d/%d/%s]", correlation.Shard, correlation.Epoch, correlation.Round, correlation.SubRound),
+		line.GetMessage(),
+		"k = v key2 = value 2 ",
+	)
+}
+
+func TestPlainFormatter_OutputShouldBeTheLegacyLayout(t *testing.T) {
+	t.Parallel()
+
+	pf := createPatternFormatter(t, logger.PlainTemplate)
+	for _, level := range logger.Levels {
+		line := createPatternTestLogLine("process/sync", "message", level)
+		assert.Equal(t, legacyPlainOutput(line), string(pf.Output(line)))
+	}
+
+	line := createPatternTestLogLine("a/very/long/logger/name", "", logger.LogInfo)
+	expected := "INFO [" + time.Unix(0, line.Timestamp).Format("2006-01-02 15:04:05.000") + "] [..long/logger/name] " +
+		"[1/2/300/(END)] " + fmt.Sprintf("%40s", "") + " k = v key2 = value 2 \n"
+	assert.Equal(t, expected, string(pf.Output(line)))
+}
+
+func TestPatternFormatter_OutputCaller(t *testing.T) {
+	t.Parallel()
+
+	line := createPatternTestLogLine("process/sync", "message", logger.LogWarning)
+	pf := createPatternFormatter(t, "%caller{brackets|space}%func{space}%msg")
+	assert.Equal(t, "message", string(pf.Output(line)))
+
+	line.Caller = proto.LogCallerMessage{
+		File:     "/go/src/github.com/multiversx/process/block.go",
+		Line:     12,
+		Function: "github.com/multiversx/process.(*block).Process",
+	}
+	assert.Equal(t, "[process/block.go:12] process.(*block).Process message", string(pf.Output(line)))
+}
+
+func TestPatternFormatter_OutputStackTrace(t *testing.T) {
+	t.Parallel()
+
+	line := createPatternTestLogLine("process/sync", "message", logger.LogError)
+	pf := createPatternFormatter(t, "%msg\n%stack")
+	assert.Equal(t, "message\n", string(pf.Output(line)))
+
+	line.StackTrace = "\tmain.main\n\t\t/src/main.go:7\n"
+	assert.Equal(t, "message\n\tmain.main\n\t\t/src/main.go:7\n", string(pf.Output(line)))
+}
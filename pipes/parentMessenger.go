@@ -44,10 +44,22 @@ func (messenger *ParentMessenger) recoverLogLine(wrapper *logger.LogLineWrapper)
 		Correlation: wrapper.Correlation,
 		Message:     wrapper.Message,
 		LogLevel:    logger.LogLevel(wrapper.LogLevel),
-		Args:        make([]interface{}, len(wrapper.Args)),
 		Timestamp:   time.Unix(0, wrapper.Timestamp),
+		Caller:      wrapper.Caller,
+		StackTrace:  wrapper.StackTrace,
 	}
 
+	if len(wrapper.TypedArgs) > 0 {
+		logLine.Args = make([]interface{}, len(wrapper.TypedArgs))
+		for i, arg := range wrapper.TypedArgs {
+			logLine.Args[i] = logger.LogArgumentValue(arg)
+		}
+
+		return logLine
+	}
+
+	// children built with older versions only send the arguments as strings
+	logLine.Args = make([]interface{}, len(wrapper.Args))
 	for i, str := range wrapper.Args {
 		logLine.Args[i] = str
 	}
@@ -3,6 +3,7 @@ package pipes
 import (
 	"os"
 	"testing"
+	"time"
 
 	"github.com/Dharitri-org/me-core/marshal"
 	"github.com/stretchr/testify/require"
@@ -45,3 +46,44 @@ func TestParentMessenger_ReadLogLine_BadJsonShouldErrWithActualJson(t *testing.T
 	require.Contains(t, err.Error(), "bad json")
 	require.Nil(t, logLine)
 }
+
+func TestParentMessenger_ReadLogLine_TypedAndStringArgs(t *testing.T) {
+	logsReader, logsWriter, err := os.Pipe()
+	require.Nil(t, err)
+	profileReader, profileWriter, err := os.Pipe()
+	require.Nil(t, err)
+
+	parentMessenger := NewParentMessenger(logsReader, profileWriter, &marshal.JsonMarshalizer{})
+	childMessenger := NewChildMessenger(profileReader, logsWriter)
+
+	// older children only send the string arguments
+	_, _ = childMessenger.SendLogLine([]byte(`{"Message": "bar", "Args": ["a", "1"]}`))
+	logLine, err := parentMessenger.ReadLogLine()
+	require.Nil(t, err)
+	require.Equal(t, []interface{}{"a", "1"}, logLine.Args)
+
+	_, _ = childMessenger.SendLogLine([]byte(`{"Message": "bar", "Args": ["a", "1", "d", "1s"], ` +
+		`"TypedArgs": [{"StringValue": "a"}, {"Int64Value": 1}, {"StringValue": "d"}, {"DurationValue": 1000000000}]}`))
+	logLine, err = parentMessenger.ReadLogLine()
+	require.Nil(t, err)
+	require.Equal(t, []interface{}{"a", int64(1), "d", time.Second}, logLine.Args)
+}
+
+func TestParentMessenger_ReadLogLine_CallerAndStackTrace(t *testing.T) {
+	logsReader, logsWriter, err := os.Pipe()
+	require.Nil(t, err)
+	profileReader, profileWriter, err := os.Pipe()
+	require.Nil(t, err)
+
+	parentMessenger := NewParentMessenger(logsReader, profileWriter, &marshal.JsonMarshalizer{})
+	childMessenger := NewChildMessenger(profileReader, logsWriter)
+
+	_, _ = childMessenger.SendLogLine([]byte(`{"Message": "bar", "Caller": {"File": "child/main.go", "Line": 7, ` +
+		`"Function": "main.main"}, "StackTrace": "\tmain.main\n\t\tchild/main.go:7\n"}`))
+	logLine, err := parentMessenger.ReadLogLine()
+	require.Nil(t, err)
+	require.Equal(t, "child/main.go", logLine.Caller.File)
+	require.Equal(t, int32(7), logLine.Caller.Line)
+	require.Equal(t, "main.main", logLine.Caller.Function)
+	require.Equal(t, "\tmain.main\n\t\tchild/main.go:7\n", logLine.StackTrace)
+}
@@ -1,56 +1,21 @@
 package logger
 
-import "fmt"
+var plainPatternFormatter = newPresetPatternFormatter(PlainTemplate)
 
 // PlainFormatter implements formatter interface and is used to format log lines to be written in the same form
-// as ConsoleFormatter but it doesn't use the ANSI colors (useful when writing to a file, for example)
+// as ConsoleFormatter but it doesn't use the ANSI colors (useful when writing to a file, for example).
+// It is the preset of the PlainTemplate
 type PlainFormatter struct {
 }
 
 // Output converts the provided LogLineHandler into a slice of bytes ready for output
 func (pf *PlainFormatter) Output(line LogLineHandler) []byte {
-	if line == nil {
-		return nil
-	}
-
-	level := LogLevel(line.GetLogLevel())
-	timestamp := displayTime(line.GetTimestamp())
-	loggerName := ""
-	correlation := ""
-	message := formatMessage(line.GetMessage())
-	args := formatArgsNoAnsi(line.GetArgs()...)
-
-	if IsEnabledLoggerName() {
-		loggerName = formatLoggerName(line.GetLoggerName())
-	}
-
-	if IsEnabledCorrelation() {
-		correlation = formatCorrelationElements(line.GetCorrelation())
-	}
-
-	return []byte(
-		fmt.Sprintf(formatPlainString,
-			level,
-			timestamp, loggerName, correlation,
-			message, args,
-		),
-	)
+	return plainPatternFormatter.Output(line)
 }
 
-// formatArgsNoAnsi iterates through the provided arguments displaying the argument name and after that its value
-// The arguments must be provided in the following format: "name1", "val1", "name2", "val2" ...
-// It ignores odd number of arguments and it does not use ANSI colors
-func formatArgsNoAnsi(args ...string) string {
-	if len(args) == 0 {
-		return ""
-	}
-
-	argString := ""
-	for index := 1; index < len(args); index += 2 {
-		argString += fmt.Sprintf("%s = %s ", args[index-1], args[index])
-	}
-
-	return argString
+// AppendOutput appends the formatted LogLineHandler to the provided buffer and returns the extended buffer
+func (pf *PlainFormatter) AppendOutput(buff []byte, line LogLineHandler) []byte {
+	return plainPatternFormatter.AppendOutput(buff, line)
 }
 
 // IsInterfaceNil returns true if there is no value under the interface
@@ -7,17 +7,29 @@ import (
 
 // Profile holds global logger options
 type Profile struct {
-	LogLevelPatterns string
-	WithCorrelation  bool
-	WithLoggerName   bool
+	LogLevelPatterns          string
+	WithCorrelation           bool
+	WithLoggerName            bool
+	WithCaller                bool
+	CallerPath                CallerPathDisplay
+	StackTracePatterns        string
+	WithStackTraceOnErrorArgs bool
+	SamplingPatterns          string
+	Observers                 []ObserverOptions
 }
 
 // GetCurrentProfile gets the current logger profile
 func GetCurrentProfile() Profile {
 	return Profile{
-		LogLevelPatterns: GetLogLevelPattern(),
-		WithCorrelation:  IsEnabledCorrelation(),
-		WithLoggerName:   IsEnabledLoggerName(),
+		LogLevelPatterns:          GetLogLevelPattern(),
+		WithCorrelation:           IsEnabledCorrelation(),
+		WithLoggerName:            IsEnabledLoggerName(),
+		WithCaller:                IsEnabledCaller(),
+		CallerPath:                GetCallerPathDisplay(),
+		StackTracePatterns:        GetStackTracePattern(),
+		WithStackTraceOnErrorArgs: IsEnabledStackTraceOnErrorArgs(),
+		SamplingPatterns:          GetSamplingPattern(),
+		Observers:                 defaultLogOut.getNamedObserversOptions(),
 	}
 }
 
@@ -42,22 +54,48 @@ func (profile *Profile) Marshal() ([]byte, error) {
 	return data, nil
 }
 
-// Apply sets the global logger options
+// Apply sets the global logger options. The observers options are applied on the observers of the default
+// log output subject having the same names, the not found names being ignored
 func (profile *Profile) Apply() error {
 	err := SetLogLevel(profile.LogLevelPatterns)
 	if err != nil {
 		return err
 	}
 
+	err = SetStackTracePattern(profile.StackTracePatterns)
+	if err != nil {
+		return err
+	}
+
+	err = SetSamplingPattern(profile.SamplingPatterns)
+	if err != nil {
+		return err
+	}
+
+	err = defaultLogOut.setNamedObserversOptions(profile.Observers)
+	if err != nil {
+		return err
+	}
+
 	ToggleCorrelation(profile.WithCorrelation)
 	ToggleLoggerName(profile.WithLoggerName)
+	ToggleCaller(profile.WithCaller)
+	SetCallerPathDisplay(profile.CallerPath)
+	ToggleStackTraceOnErrorArgs(profile.WithStackTraceOnErrorArgs)
 	return nil
 }
 
 func (profile *Profile) String() string {
-	return fmt.Sprintf("[pattern=%s, with correlation=%t, with logger name=%t]",
+	return fmt.Sprintf("[pattern=%s, with correlation=%t, with logger name=%t, with caller=%t, caller path=%d, "+
+		"stack trace pattern=%s, with stack trace on error args=%t, sampling pattern=%s, observers=%v]",
 		profile.LogLevelPatterns,
 		profile.WithCorrelation,
 		profile.WithLoggerName,
+		profile.WithCaller,
+		profile.CallerPath,
+		profile.StackTracePatterns,
+		profile.WithStackTraceOnErrorArgs,
+		profile.SamplingPatterns,
+		profile.Observers,
 	)
 }
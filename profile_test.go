@@ -1,6 +1,8 @@
 package logger
 
 import (
+	"bytes"
+	"errors"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -48,3 +50,110 @@ func TestProfile_Apply(t *testing.T) {
 	require.True(t, IsEnabledCorrelation())
 	require.False(t, IsEnabledLoggerName())
 }
+
+func TestProfile_NamedObservers(t *testing.T) {
+	w := &bytes.Buffer{}
+	err := AddLogObserverWithOptions(w, &PlainFormatter{}, ObserverOptions{Name: "profile-test", MinLevel: LogWarning})
+	require.Nil(t, err)
+	defer func() {
+		_ = RemoveLogObserver(w)
+	}()
+
+	profile := GetCurrentProfile()
+	require.Contains(t, profile.Observers, ObserverOptions{Name: "profile-test", MinLevel: LogWarning})
+
+	profile.LogLevelPatterns = "*:INFO"
+	profile.Observers = []ObserverOptions{
+		{Name: "profile-test", MinLevel: LogError, LoggerNamePattern: "process/**"},
+		{Name: "missing", MinLevel: LogError},
+	}
+	err = profile.Apply()
+	require.Nil(t, err)
+
+	profile = GetCurrentProfile()
+	require.Contains(t, profile.Observers, ObserverOptions{Name: "profile-test", MinLevel: LogError, LoggerNamePattern: "process/**"})
+	require.NotContains(t, profile.Observers, ObserverOptions{Name: "missing", MinLevel: LogError})
+}
+
+func TestProfile_Caller(t *testing.T) {
+	defer func() {
+		ToggleCaller(false)
+		SetCallerPathDisplay(CallerShortPath)
+	}()
+
+	profile := Profile{
+		LogLevelPatterns: "*:INFO",
+		WithCaller:       true,
+		CallerPath:       CallerLongPath,
+	}
+
+	json, err := profile.Marshal()
+	require.Nil(t, err)
+	profile, err = UnmarshalProfile(json)
+	require.Nil(t, err)
+
+	err = profile.Apply()
+	require.Nil(t, err)
+	require.True(t, IsEnabledCaller())
+	require.Equal(t, CallerLongPath, GetCallerPathDisplay())
+
+	current := GetCurrentProfile()
+	require.True(t, current.WithCaller)
+	require.Equal(t, CallerLongPath, current.CallerPath)
+}
+
+func TestProfile_StackTrace(t *testing.T) {
+	defer func() {
+		_ = SetStackTracePattern("")
+		ToggleStackTraceOnErrorArgs(false)
+	}()
+
+	profile := Profile{
+		LogLevelPatterns:          "*:INFO",
+		StackTracePatterns:        "*:ERROR",
+		WithStackTraceOnErrorArgs: true,
+	}
+
+	json, err := profile.Marshal()
+	require.Nil(t, err)
+	profile, err = UnmarshalProfile(json)
+	require.Nil(t, err)
+
+	err = profile.Apply()
+	require.Nil(t, err)
+	require.Equal(t, "*:ERROR", GetStackTracePattern())
+	require.True(t, IsEnabledStackTraceOnErrorArgs())
+
+	current := GetCurrentProfile()
+	require.Equal(t, "*:ERROR", current.StackTracePatterns)
+	require.True(t, current.WithStackTraceOnErrorArgs)
+
+	profile.StackTracePatterns = "*"
+	err = profile.Apply()
+	require.True(t, errors.Is(err, ErrInvalidLogLevelPattern))
+}
+
+func TestProfile_Sampling(t *testing.T) {
+	defer func() {
+		_ = SetSamplingPattern("")
+	}()
+
+	profile := Profile{
+		LogLevelPatterns: "*:INFO",
+		SamplingPatterns: "p2p/**:first=10|thereafter=100",
+	}
+
+	json, err := profile.Marshal()
+	require.Nil(t, err)
+	profile, err = UnmarshalProfile(json)
+	require.Nil(t, err)
+
+	err = profile.Apply()
+	require.Nil(t, err)
+	require.Equal(t, "p2p/**:first=10|thereafter=100", GetSamplingPattern())
+	require.Equal(t, "p2p/**:first=10|thereafter=100", GetCurrentProfile().SamplingPatterns)
+
+	profile.SamplingPatterns = "p2p/**"
+	err = profile.Apply()
+	require.True(t, errors.Is(err, ErrInvalidSamplingPattern))
+}
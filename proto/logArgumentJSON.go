@@ -0,0 +1,123 @@
+package proto
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+)
+
+const (
+	jsonInt64Value     = "Int64Value"
+	jsonUint64Value    = "Uint64Value"
+	jsonDoubleValue    = "DoubleValue"
+	jsonBoolValue      = "BoolValue"
+	jsonBytesValue     = "BytesValue"
+	jsonStringValue    = "StringValue"
+	jsonDurationValue  = "DurationValue"
+	jsonTimestampValue = "TimestampValue"
+)
+
+// MarshalJSON encodes the argument as a JSON object holding only the set value, such as {"Int64Value":5}.
+// An argument without a value is encoded as {}. The NaN and infinite double values are encoded as strings
+func (m *LogArgument) MarshalJSON() ([]byte, error) {
+	var key string
+	var value interface{}
+
+	switch v := m.GetValue().(type) {
+	case nil:
+		return []byte("{}"), nil
+	case *LogArgument_Int64Value:
+		key, value = jsonInt64Value, v.Int64Value
+	case *LogArgument_Uint64Value:
+		key, value = jsonUint64Value, v.Uint64Value
+	case *LogArgument_DoubleValue:
+		key, value = jsonDoubleValue, v.DoubleValue
+		if math.IsNaN(v.DoubleValue) || math.IsInf(v.DoubleValue, 0) {
+			value = strconv.FormatFloat(v.DoubleValue, 'g', -1, 64)
+		}
+	case *LogArgument_BoolValue:
+		key, value = jsonBoolValue, v.BoolValue
+	case *LogArgument_BytesValue:
+		key, value = jsonBytesValue, v.BytesValue
+	case *LogArgument_StringValue:
+		key, value = jsonStringValue, v.StringValue
+	case *LogArgument_DurationValue:
+		key, value = jsonDurationValue, v.DurationValue
+	case *LogArgument_TimestampValue:
+		key, value = jsonTimestampValue, v.TimestampValue
+	default:
+		return nil, fmt.Errorf("unknown log argument value type %T", v)
+	}
+
+	return json.Marshal(map[string]interface{}{key: value})
+}
+
+// UnmarshalJSON decodes an argument encoded by MarshalJSON
+func (m *LogArgument) UnmarshalJSON(data []byte) error {
+	fields := make(map[string]json.RawMessage)
+	err := json.Unmarshal(data, &fields)
+	if err != nil {
+		return err
+	}
+	if len(fields) > 1 {
+		return fmt.Errorf("log argument with %d values", len(fields))
+	}
+
+	m.Value = nil
+	for key, raw := range fields {
+		m.Value, err = unmarshalJSONLogArgumentValue(key, raw)
+	}
+
+	return err
+}
+
+func unmarshalJSONLogArgumentValue(key string, raw json.RawMessage) (isLogArgument_Value, error) {
+	var err error
+
+	switch key {
+	case jsonInt64Value:
+		v := &LogArgument_Int64Value{}
+		err = json.Unmarshal(raw, &v.Int64Value)
+		return v, err
+	case jsonUint64Value:
+		v := &LogArgument_Uint64Value{}
+		err = json.Unmarshal(raw, &v.Uint64Value)
+		return v, err
+	case jsonDoubleValue:
+		v := &LogArgument_DoubleValue{}
+		err = json.Unmarshal(raw, &v.DoubleValue)
+		if err == nil {
+			return v, nil
+		}
+		var str string
+		err = json.Unmarshal(raw, &str)
+		if err != nil {
+			return nil, err
+		}
+		v.DoubleValue, err = strconv.ParseFloat(str, 64)
+		return v, err
+	case jsonBoolValue:
+		v := &LogArgument_BoolValue{}
+		err = json.Unmarshal(raw, &v.BoolValue)
+		return v, err
+	case jsonBytesValue:
+		v := &LogArgument_BytesValue{}
+		err = json.Unmarshal(raw, &v.BytesValue)
+		return v, err
+	case jsonStringValue:
+		v := &LogArgument_StringValue{}
+		err = json.Unmarshal(raw, &v.StringValue)
+		return v, err
+	case jsonDurationValue:
+		v := &LogArgument_DurationValue{}
+		err = json.Unmarshal(raw, &v.DurationValue)
+		return v, err
+	case jsonTimestampValue:
+		v := &LogArgument_TimestampValue{}
+		err = json.Unmarshal(raw, &v.TimestampValue)
+		return v, err
+	default:
+		return nil, fmt.Errorf("unknown log argument value '%s'", key)
+	}
+}
@@ -0,0 +1,56 @@
+package proto
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLogArgument_MarshalUnmarshalJSON(t *testing.T) {
+	t.Parallel()
+
+	args := []LogArgument{
+		{},
+		{Value: &LogArgument_Int64Value{Int64Value: -5}},
+		{Value: &LogArgument_Uint64Value{Uint64Value: math.MaxUint64}},
+		{Value: &LogArgument_DoubleValue{DoubleValue: 1.5}},
+		{Value: &LogArgument_DoubleValue{DoubleValue: math.Inf(-1)}},
+		{Value: &LogArgument_BoolValue{BoolValue: true}},
+		{Value: &LogArgument_BytesValue{BytesValue: []byte{1, 2}}},
+		{Value: &LogArgument_StringValue{StringValue: "str"}},
+		{Value: &LogArgument_DurationValue{DurationValue: 1000}},
+		{Value: &LogArgument_TimestampValue{TimestampValue: 1600000000000000000}},
+	}
+
+	buff, err := json.Marshal(args)
+	require.Nil(t, err)
+	assert.Equal(t, `[{},{"Int64Value":-5},{"Uint64Value":18446744073709551615},{"DoubleValue":1.5},`+
+		`{"DoubleValue":"-Inf"},{"BoolValue":true},{"BytesValue":"AQI="},{"StringValue":"str"},`+
+		`{"DurationValue":1000},{"TimestampValue":1600000000000000000}]`, string(buff))
+
+	recovered := make([]LogArgument, 0)
+	err = json.Unmarshal(buff, &recovered)
+	require.Nil(t, err)
+	assert.Equal(t, args, recovered)
+}
+
+func TestLogArgument_UnmarshalJSONInvalidShouldErr(t *testing.T) {
+	t.Parallel()
+
+	invalid := []string{
+		`{"Int64Value":1,"BoolValue":true}`,
+		`{"UnknownValue":1}`,
+		`{"Int64Value":"1"}`,
+		`{"DoubleValue":"not a number"}`,
+		`[]`,
+	}
+
+	for _, data := range invalid {
+		arg := &LogArgument{}
+		err := json.Unmarshal([]byte(data), arg)
+		assert.NotNil(t, err, data)
+	}
+}
@@ -4,6 +4,8 @@
 package proto
 
 import (
+	bytes "bytes"
+	encoding_binary "encoding/binary"
 	fmt "fmt"
 	_ "github.com/gogo/protobuf/gogoproto"
 	proto "github.com/gogo/protobuf/proto"
@@ -32,6 +34,9 @@ type LogLineMessage struct {
 	Timestamp   int64                 `protobuf:"varint,4,opt,name=Timestamp,proto3" json:"Timestamp,omitempty"`
 	LoggerName  string                `protobuf:"bytes,5,opt,name=LoggerName,proto3" json:"LoggerName,omitempty"`
 	Correlation LogCorrelationMessage `protobuf:"bytes,6,opt,name=Correlation,proto3" json:"Correlation"`
+	TypedArgs   []LogArgument         `protobuf:"bytes,7,rep,name=TypedArgs,proto3" json:"TypedArgs"`
+	Caller      LogCallerMessage      `protobuf:"bytes,8,opt,name=Caller,proto3" json:"Caller"`
+	StackTrace  string                `protobuf:"bytes,9,opt,name=StackTrace,proto3" json:"StackTrace,omitempty"`
 }
 
 func (m *LogLineMessage) Reset()      { *m = LogLineMessage{} }
@@ -104,6 +109,27 @@ func (m *LogLineMessage) GetCorrelation() LogCorrelationMessage {
 	return LogCorrelationMessage{}
 }
 
+func (m *LogLineMessage) GetTypedArgs() []LogArgument {
+	if m != nil {
+		return m.TypedArgs
+	}
+	return nil
+}
+
+func (m *LogLineMessage) GetCaller() LogCallerMessage {
+	if m != nil {
+		return m.Caller
+	}
+	return LogCallerMessage{}
+}
+
+func (m *LogLineMessage) GetStackTrace() string {
+	if m != nil {
+		return m.StackTrace
+	}
+	return ""
+}
+
 type LogCorrelationMessage struct {
 	Shard    string `protobuf:"bytes,1,opt,name=Shard,proto3" json:"Shard,omitempty"`
 	Epoch    uint32 `protobuf:"varint,2,opt,name=Epoch,proto3" json:"Epoch,omitempty"`
@@ -167,37 +193,267 @@ func (m *LogCorrelationMessage) GetSubRound() string {
 	return ""
 }
 
+type LogArgument struct {
+	// Types that are valid to be assigned to Value:
+	//	*LogArgument_Int64Value
+	//	*LogArgument_Uint64Value
+	//	*LogArgument_DoubleValue
+	//	*LogArgument_BoolValue
+	//	*LogArgument_BytesValue
+	//	*LogArgument_StringValue
+	//	*LogArgument_DurationValue
+	//	*LogArgument_TimestampValue
+	Value isLogArgument_Value `protobuf_oneof:"Value"`
+}
+
+func (m *LogArgument) Reset()      { *m = LogArgument{} }
+func (*LogArgument) ProtoMessage() {}
+func (*LogArgument) Descriptor() ([]byte, []int) {
+	return fileDescriptor_dc96a1223a5fcf02, []int{2}
+}
+func (m *LogArgument) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *LogArgument) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	b = b[:cap(b)]
+	n, err := m.MarshalToSizedBuffer(b)
+	if err != nil {
+		return nil, err
+	}
+	return b[:n], nil
+}
+func (m *LogArgument) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LogArgument.Merge(m, src)
+}
+func (m *LogArgument) XXX_Size() int {
+	return m.Size()
+}
+func (m *LogArgument) XXX_DiscardUnknown() {
+	xxx_messageInfo_LogArgument.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LogArgument proto.InternalMessageInfo
+
+type isLogArgument_Value interface {
+	isLogArgument_Value()
+	Equal(interface{}) bool
+	MarshalTo([]byte) (int, error)
+	Size() int
+}
+
+type LogArgument_Int64Value struct {
+	Int64Value int64 `protobuf:"varint,1,opt,name=Int64Value,proto3,oneof" json:"Int64Value,omitempty"`
+}
+type LogArgument_Uint64Value struct {
+	Uint64Value uint64 `protobuf:"varint,2,opt,name=Uint64Value,proto3,oneof" json:"Uint64Value,omitempty"`
+}
+type LogArgument_DoubleValue struct {
+	DoubleValue float64 `protobuf:"fixed64,3,opt,name=DoubleValue,proto3,oneof" json:"DoubleValue,omitempty"`
+}
+type LogArgument_BoolValue struct {
+	BoolValue bool `protobuf:"varint,4,opt,name=BoolValue,proto3,oneof" json:"BoolValue,omitempty"`
+}
+type LogArgument_BytesValue struct {
+	BytesValue []byte `protobuf:"bytes,5,opt,name=BytesValue,proto3,oneof" json:"BytesValue,omitempty"`
+}
+type LogArgument_StringValue struct {
+	StringValue string `protobuf:"bytes,6,opt,name=StringValue,proto3,oneof" json:"StringValue,omitempty"`
+}
+type LogArgument_DurationValue struct {
+	DurationValue int64 `protobuf:"varint,7,opt,name=DurationValue,proto3,oneof" json:"DurationValue,omitempty"`
+}
+type LogArgument_TimestampValue struct {
+	TimestampValue int64 `protobuf:"varint,8,opt,name=TimestampValue,proto3,oneof" json:"TimestampValue,omitempty"`
+}
+
+func (*LogArgument_Int64Value) isLogArgument_Value()     {}
+func (*LogArgument_Uint64Value) isLogArgument_Value()    {}
+func (*LogArgument_DoubleValue) isLogArgument_Value()    {}
+func (*LogArgument_BoolValue) isLogArgument_Value()      {}
+func (*LogArgument_BytesValue) isLogArgument_Value()     {}
+func (*LogArgument_StringValue) isLogArgument_Value()    {}
+func (*LogArgument_DurationValue) isLogArgument_Value()  {}
+func (*LogArgument_TimestampValue) isLogArgument_Value() {}
+
+func (m *LogArgument) GetValue() isLogArgument_Value {
+	if m != nil {
+		return m.Value
+	}
+	return nil
+}
+
+func (m *LogArgument) GetInt64Value() int64 {
+	if x, ok := m.GetValue().(*LogArgument_Int64Value); ok {
+		return x.Int64Value
+	}
+	return 0
+}
+
+func (m *LogArgument) GetUint64Value() uint64 {
+	if x, ok := m.GetValue().(*LogArgument_Uint64Value); ok {
+		return x.Uint64Value
+	}
+	return 0
+}
+
+func (m *LogArgument) GetDoubleValue() float64 {
+	if x, ok := m.GetValue().(*LogArgument_DoubleValue); ok {
+		return x.DoubleValue
+	}
+	return 0
+}
+
+func (m *LogArgument) GetBoolValue() bool {
+	if x, ok := m.GetValue().(*LogArgument_BoolValue); ok {
+		return x.BoolValue
+	}
+	return false
+}
+
+func (m *LogArgument) GetBytesValue() []byte {
+	if x, ok := m.GetValue().(*LogArgument_BytesValue); ok {
+		return x.BytesValue
+	}
+	return nil
+}
+
+func (m *LogArgument) GetStringValue() string {
+	if x, ok := m.GetValue().(*LogArgument_StringValue); ok {
+		return x.StringValue
+	}
+	return ""
+}
+
+func (m *LogArgument) GetDurationValue() int64 {
+	if x, ok := m.GetValue().(*LogArgument_DurationValue); ok {
+		return x.DurationValue
+	}
+	return 0
+}
+
+func (m *LogArgument) GetTimestampValue() int64 {
+	if x, ok := m.GetValue().(*LogArgument_TimestampValue); ok {
+		return x.TimestampValue
+	}
+	return 0
+}
+
+// XXX_OneofWrappers is for the internal use of the proto package.
+func (*LogArgument) XXX_OneofWrappers() []interface{} {
+	return []interface{}{
+		(*LogArgument_Int64Value)(nil),
+		(*LogArgument_Uint64Value)(nil),
+		(*LogArgument_DoubleValue)(nil),
+		(*LogArgument_BoolValue)(nil),
+		(*LogArgument_BytesValue)(nil),
+		(*LogArgument_StringValue)(nil),
+		(*LogArgument_DurationValue)(nil),
+		(*LogArgument_TimestampValue)(nil),
+	}
+}
+
+type LogCallerMessage struct {
+	File     string `protobuf:"bytes,1,opt,name=File,proto3" json:"File,omitempty"`
+	Line     int32  `protobuf:"varint,2,opt,name=Line,proto3" json:"Line,omitempty"`
+	Function string `protobuf:"bytes,3,opt,name=Function,proto3" json:"Function,omitempty"`
+}
+
+func (m *LogCallerMessage) Reset()      { *m = LogCallerMessage{} }
+func (*LogCallerMessage) ProtoMessage() {}
+func (*LogCallerMessage) Descriptor() ([]byte, []int) {
+	return fileDescriptor_dc96a1223a5fcf02, []int{3}
+}
+func (m *LogCallerMessage) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *LogCallerMessage) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	b = b[:cap(b)]
+	n, err := m.MarshalToSizedBuffer(b)
+	if err != nil {
+		return nil, err
+	}
+	return b[:n], nil
+}
+func (m *LogCallerMessage) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LogCallerMessage.Merge(m, src)
+}
+func (m *LogCallerMessage) XXX_Size() int {
+	return m.Size()
+}
+func (m *LogCallerMessage) XXX_DiscardUnknown() {
+	xxx_messageInfo_LogCallerMessage.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LogCallerMessage proto.InternalMessageInfo
+
+func (m *LogCallerMessage) GetFile() string {
+	if m != nil {
+		return m.File
+	}
+	return ""
+}
+
+func (m *LogCallerMessage) GetLine() int32 {
+	if m != nil {
+		return m.Line
+	}
+	return 0
+}
+
+func (m *LogCallerMessage) GetFunction() string {
+	if m != nil {
+		return m.Function
+	}
+	return ""
+}
+
 func init() {
 	proto.RegisterType((*LogLineMessage)(nil), "proto.LogLineMessage")
 	proto.RegisterType((*LogCorrelationMessage)(nil), "proto.LogCorrelationMessage")
+	proto.RegisterType((*LogArgument)(nil), "proto.LogArgument")
+	proto.RegisterType((*LogCallerMessage)(nil), "proto.LogCallerMessage")
 }
 
 func init() { proto.RegisterFile("logLineMessage.proto", fileDescriptor_dc96a1223a5fcf02) }
 
 var fileDescriptor_dc96a1223a5fcf02 = []byte{
-	// 338 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x6c, 0x91, 0xcd, 0x4e, 0xf2, 0x40,
-	0x14, 0x86, 0x7b, 0xbe, 0xb6, 0x7c, 0x32, 0x44, 0x17, 0x13, 0x4c, 0x26, 0x84, 0x1c, 0x1b, 0x56,
-	0xdd, 0x08, 0x89, 0x5e, 0x80, 0x11, 0x75, 0x57, 0x5d, 0x14, 0x57, 0xee, 0x5a, 0x18, 0x87, 0x26,
-	0x94, 0x21, 0xfd, 0x71, 0xed, 0x25, 0x78, 0x19, 0x5e, 0x0a, 0x4b, 0x96, 0xac, 0x88, 0x0c, 0x1b,
-	0x97, 0x5c, 0x82, 0xe9, 0x0c, 0x22, 0x26, 0xae, 0xce, 0x79, 0xde, 0xf3, 0xd3, 0xf3, 0x76, 0x48,
-	0x73, 0x22, 0x45, 0x90, 0x4c, 0xf9, 0x3d, 0xcf, 0xf3, 0x48, 0xf0, 0xee, 0x2c, 0x93, 0x85, 0xa4,
-	0xae, 0x0e, 0xad, 0x73, 0x91, 0x14, 0xe3, 0x32, 0xee, 0x0e, 0x65, 0xda, 0x13, 0x52, 0xc8, 0x9e,
-	0x96, 0xe3, 0xf2, 0x59, 0x93, 0x06, 0x9d, 0x99, 0xa9, 0xce, 0x0a, 0xc8, 0x49, 0xf0, 0x6b, 0x1d,
-	0x65, 0xe4, 0xff, 0x2e, 0x65, 0xe0, 0x81, 0x5f, 0x0f, 0xbf, 0x91, 0xb6, 0xc8, 0x51, 0xd5, 0xcb,
-	0x5f, 0xf8, 0x84, 0xfd, 0xf3, 0xc0, 0x77, 0xc3, 0x3d, 0x53, 0x4a, 0x9c, 0xeb, 0x4c, 0xe4, 0xcc,
-	0xf6, 0x6c, 0xbf, 0x1e, 0xea, 0x9c, 0xb6, 0x49, 0xfd, 0x31, 0x49, 0x79, 0x5e, 0x44, 0xe9, 0x8c,
-	0x39, 0x1e, 0xf8, 0x76, 0xf8, 0x23, 0x50, 0x24, 0x24, 0x90, 0x42, 0xf0, 0xec, 0x21, 0x4a, 0x39,
-	0x73, 0xf5, 0xa7, 0x0e, 0x14, 0x7a, 0x4b, 0x1a, 0x37, 0x32, 0xcb, 0xf8, 0x24, 0x2a, 0x12, 0x39,
-	0x65, 0x35, 0x0f, 0xfc, 0xc6, 0x45, 0xdb, 0xdc, 0xdd, 0x0d, 0xa4, 0x38, 0x28, 0xee, 0x0e, 0xec,
-	0x3b, 0xf3, 0xd5, 0x99, 0x15, 0x1e, 0x8e, 0x75, 0x4a, 0x72, 0xfa, 0x67, 0x2f, 0x6d, 0x12, 0x77,
-	0x30, 0x8e, 0xb2, 0xd1, 0xce, 0xa4, 0x81, 0x4a, 0xbd, 0x9b, 0xc9, 0xe1, 0x58, 0xfb, 0x3b, 0x0e,
-	0x0d, 0x54, 0x6a, 0x28, 0xcb, 0xe9, 0x88, 0xd9, 0xda, 0x84, 0x81, 0xea, 0x77, 0x0c, 0xca, 0xd8,
-	0x14, 0x1c, 0xbd, 0x64, 0xcf, 0xfd, 0xab, 0xc5, 0x1a, 0xad, 0xe5, 0x1a, 0xad, 0xed, 0x1a, 0xe1,
-	0x55, 0x21, 0xbc, 0x2b, 0x84, 0xb9, 0x42, 0x58, 0x28, 0x84, 0xa5, 0x42, 0xf8, 0x50, 0x08, 0x9f,
-	0x0a, 0xad, 0xad, 0x42, 0x78, 0xdb, 0xa0, 0xb5, 0xd8, 0xa0, 0xb5, 0xdc, 0xa0, 0xf5, 0x64, 0xde,
-	0x31, 0xae, 0xe9, 0x70, 0xf9, 0x15, 0x00, 0x00, 0xff, 0xff, 0xaf, 0xd3, 0x9b, 0x1d, 0xed, 0x01,
-	0x00, 0x00,
+	// 569 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x6c, 0x93, 0xcf, 0x6e, 0xd3, 0x40,
+	0x10, 0xc6, 0xbd, 0x75, 0xfe, 0x79, 0x42, 0x2b, 0xb4, 0x2a, 0xc2, 0xaa, 0xaa, 0xc5, 0xca, 0x01,
+	0xf9, 0x42, 0x2a, 0x15, 0xe8, 0x15, 0x35, 0x0d, 0x55, 0x90, 0x02, 0x07, 0x27, 0xf4, 0xc0, 0xcd,
+	0x49, 0x96, 0x8d, 0x85, 0xe3, 0x8d, 0x36, 0x5e, 0xa4, 0xde, 0x78, 0x04, 0x1e, 0x83, 0x47, 0xe0,
+	0x11, 0x7a, 0xcc, 0x31, 0x27, 0x44, 0x9c, 0x0b, 0x17, 0xa4, 0x3e, 0x02, 0xda, 0x5d, 0x27, 0x76,
+	0x2a, 0x4e, 0x9e, 0xef, 0xe7, 0x6f, 0x77, 0x66, 0x77, 0x66, 0xe1, 0x38, 0xe6, 0xac, 0x1f, 0x25,
+	0xf4, 0x3d, 0x5d, 0x2c, 0x42, 0x46, 0xdb, 0x73, 0xc1, 0x53, 0x8e, 0xab, 0xfa, 0x73, 0xf2, 0x82,
+	0x45, 0xe9, 0x54, 0x8e, 0xda, 0x63, 0x3e, 0x3b, 0x63, 0x9c, 0xf1, 0x33, 0x8d, 0x47, 0xf2, 0xb3,
+	0x56, 0x5a, 0xe8, 0xc8, 0xac, 0x6a, 0xfd, 0x3d, 0x80, 0xa3, 0xfe, 0xde, 0x76, 0xd8, 0x85, 0x7a,
+	0x1e, 0xba, 0xc8, 0x43, 0xbe, 0x13, 0x6c, 0x25, 0x3e, 0x81, 0x86, 0xf2, 0xd2, 0xaf, 0x34, 0x76,
+	0x0f, 0x3c, 0xe4, 0x57, 0x83, 0x9d, 0xc6, 0x18, 0x2a, 0x97, 0x82, 0x2d, 0x5c, 0xdb, 0xb3, 0x7d,
+	0x27, 0xd0, 0x31, 0x3e, 0x05, 0x67, 0x18, 0xcd, 0xe8, 0x22, 0x0d, 0x67, 0x73, 0xb7, 0xe2, 0x21,
+	0xdf, 0x0e, 0x0a, 0x80, 0x09, 0x40, 0x9f, 0x33, 0x46, 0xc5, 0x87, 0x70, 0x46, 0xdd, 0xaa, 0x4e,
+	0x55, 0x22, 0xb8, 0x0b, 0xcd, 0x2b, 0x2e, 0x04, 0x8d, 0xc3, 0x34, 0xe2, 0x89, 0x5b, 0xf3, 0x90,
+	0xdf, 0x3c, 0x3f, 0x35, 0x75, 0xb7, 0xfb, 0x9c, 0x95, 0x7e, 0xe6, 0x05, 0x76, 0x2a, 0x77, 0xbf,
+	0x9e, 0x59, 0x41, 0x79, 0x19, 0xbe, 0x00, 0x67, 0x78, 0x3b, 0xa7, 0x13, 0x5d, 0x5c, 0xdd, 0xb3,
+	0xfd, 0xe6, 0x39, 0x2e, 0xf6, 0xb8, 0x14, 0x4c, 0xce, 0x68, 0x92, 0xe6, 0x2b, 0x0b, 0x2b, 0x7e,
+	0x0d, 0xb5, 0xab, 0x30, 0x8e, 0xa9, 0x70, 0x1b, 0x3a, 0xf1, 0xd3, 0x52, 0x62, 0xcd, 0xf7, 0x73,
+	0xe6, 0x66, 0x75, 0xa8, 0x41, 0x1a, 0x8e, 0xbf, 0x0c, 0x45, 0x38, 0xa6, 0xae, 0x63, 0x0e, 0x55,
+	0x90, 0x96, 0x84, 0x27, 0xff, 0x2d, 0x1d, 0x1f, 0x43, 0x75, 0x30, 0x0d, 0xc5, 0x24, 0xbf, 0x73,
+	0x23, 0x14, 0x7d, 0x3b, 0xe7, 0xe3, 0xa9, 0xbe, 0xee, 0xc3, 0xc0, 0x08, 0x45, 0x03, 0x2e, 0x93,
+	0x89, 0x6b, 0xeb, 0x3b, 0x35, 0x42, 0x75, 0x67, 0x20, 0x47, 0xe6, 0x47, 0x45, 0x6f, 0xb2, 0xd3,
+	0xad, 0x9f, 0x07, 0xd0, 0x2c, 0x1d, 0x17, 0x7b, 0x00, 0xef, 0x92, 0xf4, 0xe2, 0xd5, 0x4d, 0x18,
+	0x4b, 0xd3, 0x66, 0xbb, 0x67, 0x05, 0x25, 0x86, 0x5b, 0xd0, 0xfc, 0x18, 0x15, 0x16, 0x95, 0xbf,
+	0xd2, 0xb3, 0x82, 0x32, 0x54, 0x9e, 0x2e, 0x97, 0xa3, 0x98, 0x1a, 0x8f, 0xaa, 0x06, 0x29, 0x4f,
+	0x09, 0x62, 0x02, 0x4e, 0x87, 0xf3, 0xd8, 0x38, 0x54, 0x59, 0x8d, 0x9e, 0x15, 0x14, 0x48, 0x55,
+	0xd2, 0xb9, 0x4d, 0xe9, 0xc2, 0x18, 0xd4, 0x14, 0x3c, 0x52, 0x95, 0x14, 0x4c, 0x65, 0x19, 0xa4,
+	0x22, 0x4a, 0x98, 0xb1, 0xa8, 0x39, 0x70, 0x54, 0x96, 0x12, 0xc4, 0xcf, 0xe1, 0xb0, 0x2b, 0x85,
+	0xbe, 0x50, 0xe3, 0xaa, 0xe7, 0x47, 0xda, 0xc7, 0xd8, 0x87, 0xa3, 0xdd, 0x00, 0x1a, 0x63, 0x23,
+	0x37, 0x3e, 0xe0, 0x9d, 0x3a, 0x54, 0x75, 0xd0, 0xba, 0x81, 0xc7, 0x0f, 0x7b, 0xae, 0x86, 0xfd,
+	0x3a, 0x8a, 0xb7, 0xef, 0x43, 0xc7, 0x8a, 0xa9, 0x57, 0x94, 0x3f, 0x0c, 0x1d, 0xab, 0x96, 0x5c,
+	0xcb, 0x64, 0xac, 0xe7, 0xd7, 0x36, 0x2d, 0xd9, 0xea, 0xce, 0x9b, 0xe5, 0x9a, 0x58, 0xab, 0x35,
+	0xb1, 0xee, 0xd7, 0x04, 0x7d, 0xcb, 0x08, 0xfa, 0x91, 0x11, 0x74, 0x97, 0x11, 0xb4, 0xcc, 0x08,
+	0x5a, 0x65, 0x04, 0xfd, 0xce, 0x08, 0xfa, 0x93, 0x11, 0xeb, 0x3e, 0x23, 0xe8, 0xfb, 0x86, 0x58,
+	0xcb, 0x0d, 0xb1, 0x56, 0x1b, 0x62, 0x7d, 0x32, 0x2f, 0x7d, 0x54, 0xd3, 0x9f, 0x97, 0xff, 0x06,
+	0x00, 0x23, 0xf1, 0x86, 0xf5, 0x0f, 0x04, 0x00, 0x00,
 }
 
 func (this *LogLineMessage) Equal(that interface{}) bool {
@@ -242,6 +498,20 @@ func (this *LogLineMessage) Equal(that interface{}) bool {
 	if !this.Correlation.Equal(&that1.Correlation) {
 		return false
 	}
+	if len(this.TypedArgs) != len(that1.TypedArgs) {
+		return false
+	}
+	for i := range this.TypedArgs {
+		if !this.TypedArgs[i].Equal(&that1.TypedArgs[i]) {
+			return false
+		}
+	}
+	if !this.Caller.Equal(&that1.Caller) {
+		return false
+	}
+	if this.StackTrace != that1.StackTrace {
+		return false
+	}
 	return true
 }
 func (this *LogCorrelationMessage) Equal(that interface{}) bool {
@@ -277,263 +547,1371 @@ func (this *LogCorrelationMessage) Equal(that interface{}) bool {
 	}
 	return true
 }
-func (this *LogLineMessage) GoString() string {
-	if this == nil {
-		return "nil"
+func (this *LogArgument) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
 	}
-	s := make([]string, 0, 10)
-	s = append(s, "&proto.LogLineMessage{")
-	s = append(s, "Message: "+fmt.Sprintf("%#v", this.Message)+",\n")
-	s = append(s, "LogLevel: "+fmt.Sprintf("%#v", this.LogLevel)+",\n")
-	s = append(s, "Args: "+fmt.Sprintf("%#v", this.Args)+",\n")
-	s = append(s, "Timestamp: "+fmt.Sprintf("%#v", this.Timestamp)+",\n")
-	s = append(s, "LoggerName: "+fmt.Sprintf("%#v", this.LoggerName)+",\n")
-	s = append(s, "Correlation: "+strings.Replace(this.Correlation.GoString(), `&`, ``, 1)+",\n")
-	s = append(s, "}")
-	return strings.Join(s, "")
-}
-func (this *LogCorrelationMessage) GoString() string {
-	if this == nil {
-		return "nil"
+
+	that1, ok := that.(*LogArgument)
+	if !ok {
+		that2, ok := that.(LogArgument)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	s := make([]string, 0, 8)
-	s = append(s, "&proto.LogCorrelationMessage{")
-	s = append(s, "Shard: "+fmt.Sprintf("%#v", this.Shard)+",\n")
-	s = append(s, "Epoch: "+fmt.Sprintf("%#v", this.Epoch)+",\n")
-	s = append(s, "Round: "+fmt.Sprintf("%#v", this.Round)+",\n")
-	s = append(s, "SubRound: "+fmt.Sprintf("%#v", this.SubRound)+",\n")
-	s = append(s, "}")
-	return strings.Join(s, "")
-}
-func valueToGoStringLogLineMessage(v interface{}, typ string) string {
-	rv := reflect.ValueOf(v)
-	if rv.IsNil() {
-		return "nil"
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	pv := reflect.Indirect(rv).Interface()
-	return fmt.Sprintf("func(v %v) *%v { return &v } ( %#v )", typ, typ, pv)
-}
-func (m *LogLineMessage) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+	if that1.Value == nil {
+		if this.Value != nil {
+			return false
+		}
+	} else if this.Value == nil {
+		return false
+	} else if !this.Value.Equal(that1.Value) {
+		return false
 	}
-	return dAtA[:n], nil
-}
-
-func (m *LogLineMessage) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+	return true
 }
+func (this *LogArgument_Int64Value) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
 
-func (m *LogLineMessage) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	{
-		size, err := m.Correlation.MarshalToSizedBuffer(dAtA[:i])
-		if err != nil {
-			return 0, err
+	that1, ok := that.(*LogArgument_Int64Value)
+	if !ok {
+		that2, ok := that.(LogArgument_Int64Value)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
 		}
-		i -= size
-		i = encodeVarintLogLineMessage(dAtA, i, uint64(size))
 	}
-	i--
-	dAtA[i] = 0x32
-	if len(m.LoggerName) > 0 {
-		i -= len(m.LoggerName)
-		copy(dAtA[i:], m.LoggerName)
-		i = encodeVarintLogLineMessage(dAtA, i, uint64(len(m.LoggerName)))
-		i--
-		dAtA[i] = 0x2a
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	if m.Timestamp != 0 {
-		i = encodeVarintLogLineMessage(dAtA, i, uint64(m.Timestamp))
-		i--
-		dAtA[i] = 0x20
+	if this.Int64Value != that1.Int64Value {
+		return false
 	}
-	if len(m.Args) > 0 {
-		for iNdEx := len(m.Args) - 1; iNdEx >= 0; iNdEx-- {
-			i -= len(m.Args[iNdEx])
-			copy(dAtA[i:], m.Args[iNdEx])
-			i = encodeVarintLogLineMessage(dAtA, i, uint64(len(m.Args[iNdEx])))
-			i--
-			dAtA[i] = 0x1a
+	return true
+}
+func (this *LogArgument_Uint64Value) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*LogArgument_Uint64Value)
+	if !ok {
+		that2, ok := that.(LogArgument_Uint64Value)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
 		}
 	}
-	if m.LogLevel != 0 {
-		i = encodeVarintLogLineMessage(dAtA, i, uint64(m.LogLevel))
-		i--
-		dAtA[i] = 0x10
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	if len(m.Message) > 0 {
-		i -= len(m.Message)
-		copy(dAtA[i:], m.Message)
-		i = encodeVarintLogLineMessage(dAtA, i, uint64(len(m.Message)))
-		i--
-		dAtA[i] = 0xa
+	if this.Uint64Value != that1.Uint64Value {
+		return false
 	}
-	return len(dAtA) - i, nil
+	return true
 }
+func (this *LogArgument_DoubleValue) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
 
-func (m *LogCorrelationMessage) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+	that1, ok := that.(*LogArgument_DoubleValue)
+	if !ok {
+		that2, ok := that.(LogArgument_DoubleValue)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	return dAtA[:n], nil
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.DoubleValue != that1.DoubleValue {
+		return false
+	}
+	return true
 }
+func (this *LogArgument_BoolValue) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
 
-func (m *LogCorrelationMessage) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+	that1, ok := that.(*LogArgument_BoolValue)
+	if !ok {
+		that2, ok := that.(LogArgument_BoolValue)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.BoolValue != that1.BoolValue {
+		return false
+	}
+	return true
 }
-
-func (m *LogCorrelationMessage) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if len(m.SubRound) > 0 {
-		i -= len(m.SubRound)
-		copy(dAtA[i:], m.SubRound)
-		i = encodeVarintLogLineMessage(dAtA, i, uint64(len(m.SubRound)))
-		i--
-		dAtA[i] = 0x22
+func (this *LogArgument_BytesValue) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
 	}
-	if m.Round != 0 {
-		i = encodeVarintLogLineMessage(dAtA, i, uint64(m.Round))
-		i--
-		dAtA[i] = 0x18
+
+	that1, ok := that.(*LogArgument_BytesValue)
+	if !ok {
+		that2, ok := that.(LogArgument_BytesValue)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	if m.Epoch != 0 {
-		i = encodeVarintLogLineMessage(dAtA, i, uint64(m.Epoch))
-		i--
-		dAtA[i] = 0x10
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	if len(m.Shard) > 0 {
-		i -= len(m.Shard)
-		copy(dAtA[i:], m.Shard)
-		i = encodeVarintLogLineMessage(dAtA, i, uint64(len(m.Shard)))
-		i--
-		dAtA[i] = 0xa
+	if !bytes.Equal(this.BytesValue, that1.BytesValue) {
+		return false
 	}
-	return len(dAtA) - i, nil
+	return true
 }
+func (this *LogArgument_StringValue) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
 
-func encodeVarintLogLineMessage(dAtA []byte, offset int, v uint64) int {
-	offset -= sovLogLineMessage(v)
-	base := offset
-	for v >= 1<<7 {
-		dAtA[offset] = uint8(v&0x7f | 0x80)
-		v >>= 7
-		offset++
+	that1, ok := that.(*LogArgument_StringValue)
+	if !ok {
+		that2, ok := that.(LogArgument_StringValue)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	dAtA[offset] = uint8(v)
-	return base
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.StringValue != that1.StringValue {
+		return false
+	}
+	return true
 }
-func (m *LogLineMessage) Size() (n int) {
-	if m == nil {
-		return 0
+func (this *LogArgument_DurationValue) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
 	}
-	var l int
-	_ = l
-	l = len(m.Message)
-	if l > 0 {
-		n += 1 + l + sovLogLineMessage(uint64(l))
+
+	that1, ok := that.(*LogArgument_DurationValue)
+	if !ok {
+		that2, ok := that.(LogArgument_DurationValue)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	if m.LogLevel != 0 {
-		n += 1 + sovLogLineMessage(uint64(m.LogLevel))
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	if len(m.Args) > 0 {
-		for _, s := range m.Args {
-			l = len(s)
-			n += 1 + l + sovLogLineMessage(uint64(l))
+	if this.DurationValue != that1.DurationValue {
+		return false
+	}
+	return true
+}
+func (this *LogArgument_TimestampValue) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*LogArgument_TimestampValue)
+	if !ok {
+		that2, ok := that.(LogArgument_TimestampValue)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
 		}
 	}
-	if m.Timestamp != 0 {
-		n += 1 + sovLogLineMessage(uint64(m.Timestamp))
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	l = len(m.LoggerName)
-	if l > 0 {
-		n += 1 + l + sovLogLineMessage(uint64(l))
+	if this.TimestampValue != that1.TimestampValue {
+		return false
 	}
-	l = m.Correlation.Size()
-	n += 1 + l + sovLogLineMessage(uint64(l))
-	return n
+	return true
 }
+func (this *LogCallerMessage) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
 
-func (m *LogCorrelationMessage) Size() (n int) {
-	if m == nil {
-		return 0
+	that1, ok := that.(*LogCallerMessage)
+	if !ok {
+		that2, ok := that.(LogCallerMessage)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	var l int
-	_ = l
-	l = len(m.Shard)
-	if l > 0 {
-		n += 1 + l + sovLogLineMessage(uint64(l))
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	if m.Epoch != 0 {
-		n += 1 + sovLogLineMessage(uint64(m.Epoch))
+	if this.File != that1.File {
+		return false
 	}
-	if m.Round != 0 {
-		n += 1 + sovLogLineMessage(uint64(m.Round))
+	if this.Line != that1.Line {
+		return false
 	}
-	l = len(m.SubRound)
-	if l > 0 {
-		n += 1 + l + sovLogLineMessage(uint64(l))
+	if this.Function != that1.Function {
+		return false
+	}
+	return true
+}
+func (this *LogLineMessage) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 13)
+	s = append(s, "&proto.LogLineMessage{")
+	s = append(s, "Message: "+fmt.Sprintf("%#v", this.Message)+",\n")
+	s = append(s, "LogLevel: "+fmt.Sprintf("%#v", this.LogLevel)+",\n")
+	s = append(s, "Args: "+fmt.Sprintf("%#v", this.Args)+",\n")
+	s = append(s, "Timestamp: "+fmt.Sprintf("%#v", this.Timestamp)+",\n")
+	s = append(s, "LoggerName: "+fmt.Sprintf("%#v", this.LoggerName)+",\n")
+	s = append(s, "Correlation: "+strings.Replace(this.Correlation.GoString(), `&`, ``, 1)+",\n")
+	if this.TypedArgs != nil {
+		vs := make([]LogArgument, len(this.TypedArgs))
+		for i := range vs {
+			vs[i] = this.TypedArgs[i]
+		}
+		s = append(s, "TypedArgs: "+fmt.Sprintf("%#v", vs)+",\n")
+	}
+	s = append(s, "Caller: "+strings.Replace(this.Caller.GoString(), `&`, ``, 1)+",\n")
+	s = append(s, "StackTrace: "+fmt.Sprintf("%#v", this.StackTrace)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *LogCorrelationMessage) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 8)
+	s = append(s, "&proto.LogCorrelationMessage{")
+	s = append(s, "Shard: "+fmt.Sprintf("%#v", this.Shard)+",\n")
+	s = append(s, "Epoch: "+fmt.Sprintf("%#v", this.Epoch)+",\n")
+	s = append(s, "Round: "+fmt.Sprintf("%#v", this.Round)+",\n")
+	s = append(s, "SubRound: "+fmt.Sprintf("%#v", this.SubRound)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *LogArgument) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 12)
+	s = append(s, "&proto.LogArgument{")
+	if this.Value != nil {
+		s = append(s, "Value: "+fmt.Sprintf("%#v", this.Value)+",\n")
+	}
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *LogArgument_Int64Value) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&proto.LogArgument_Int64Value{` +
+		`Int64Value:` + fmt.Sprintf("%#v", this.Int64Value) + `}`}, ", ")
+	return s
+}
+func (this *LogArgument_Uint64Value) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&proto.LogArgument_Uint64Value{` +
+		`Uint64Value:` + fmt.Sprintf("%#v", this.Uint64Value) + `}`}, ", ")
+	return s
+}
+func (this *LogArgument_DoubleValue) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&proto.LogArgument_DoubleValue{` +
+		`DoubleValue:` + fmt.Sprintf("%#v", this.DoubleValue) + `}`}, ", ")
+	return s
+}
+func (this *LogArgument_BoolValue) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&proto.LogArgument_BoolValue{` +
+		`BoolValue:` + fmt.Sprintf("%#v", this.BoolValue) + `}`}, ", ")
+	return s
+}
+func (this *LogArgument_BytesValue) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&proto.LogArgument_BytesValue{` +
+		`BytesValue:` + fmt.Sprintf("%#v", this.BytesValue) + `}`}, ", ")
+	return s
+}
+func (this *LogArgument_StringValue) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&proto.LogArgument_StringValue{` +
+		`StringValue:` + fmt.Sprintf("%#v", this.StringValue) + `}`}, ", ")
+	return s
+}
+func (this *LogArgument_DurationValue) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&proto.LogArgument_DurationValue{` +
+		`DurationValue:` + fmt.Sprintf("%#v", this.DurationValue) + `}`}, ", ")
+	return s
+}
+func (this *LogArgument_TimestampValue) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&proto.LogArgument_TimestampValue{` +
+		`TimestampValue:` + fmt.Sprintf("%#v", this.TimestampValue) + `}`}, ", ")
+	return s
+}
+func (this *LogCallerMessage) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 7)
+	s = append(s, "&proto.LogCallerMessage{")
+	s = append(s, "File: "+fmt.Sprintf("%#v", this.File)+",\n")
+	s = append(s, "Line: "+fmt.Sprintf("%#v", this.Line)+",\n")
+	s = append(s, "Function: "+fmt.Sprintf("%#v", this.Function)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func valueToGoStringLogLineMessage(v interface{}, typ string) string {
+	rv := reflect.ValueOf(v)
+	if rv.IsNil() {
+		return "nil"
+	}
+	pv := reflect.Indirect(rv).Interface()
+	return fmt.Sprintf("func(v %v) *%v { return &v } ( %#v )", typ, typ, pv)
+}
+func (m *LogLineMessage) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *LogLineMessage) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *LogLineMessage) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.StackTrace) > 0 {
+		i -= len(m.StackTrace)
+		copy(dAtA[i:], m.StackTrace)
+		i = encodeVarintLogLineMessage(dAtA, i, uint64(len(m.StackTrace)))
+		i--
+		dAtA[i] = 0x4a
+	}
+	{
+		size, err := m.Caller.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintLogLineMessage(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0x42
+	if len(m.TypedArgs) > 0 {
+		for iNdEx := len(m.TypedArgs) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.TypedArgs[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintLogLineMessage(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x3a
+		}
+	}
+	{
+		size, err := m.Correlation.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintLogLineMessage(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0x32
+	if len(m.LoggerName) > 0 {
+		i -= len(m.LoggerName)
+		copy(dAtA[i:], m.LoggerName)
+		i = encodeVarintLogLineMessage(dAtA, i, uint64(len(m.LoggerName)))
+		i--
+		dAtA[i] = 0x2a
+	}
+	if m.Timestamp != 0 {
+		i = encodeVarintLogLineMessage(dAtA, i, uint64(m.Timestamp))
+		i--
+		dAtA[i] = 0x20
+	}
+	if len(m.Args) > 0 {
+		for iNdEx := len(m.Args) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Args[iNdEx])
+			copy(dAtA[i:], m.Args[iNdEx])
+			i = encodeVarintLogLineMessage(dAtA, i, uint64(len(m.Args[iNdEx])))
+			i--
+			dAtA[i] = 0x1a
+		}
+	}
+	if m.LogLevel != 0 {
+		i = encodeVarintLogLineMessage(dAtA, i, uint64(m.LogLevel))
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.Message) > 0 {
+		i -= len(m.Message)
+		copy(dAtA[i:], m.Message)
+		i = encodeVarintLogLineMessage(dAtA, i, uint64(len(m.Message)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *LogCorrelationMessage) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *LogCorrelationMessage) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *LogCorrelationMessage) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.SubRound) > 0 {
+		i -= len(m.SubRound)
+		copy(dAtA[i:], m.SubRound)
+		i = encodeVarintLogLineMessage(dAtA, i, uint64(len(m.SubRound)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if m.Round != 0 {
+		i = encodeVarintLogLineMessage(dAtA, i, uint64(m.Round))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.Epoch != 0 {
+		i = encodeVarintLogLineMessage(dAtA, i, uint64(m.Epoch))
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.Shard) > 0 {
+		i -= len(m.Shard)
+		copy(dAtA[i:], m.Shard)
+		i = encodeVarintLogLineMessage(dAtA, i, uint64(len(m.Shard)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *LogArgument) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *LogArgument) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *LogArgument) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Value != nil {
+		{
+			size := m.Value.Size()
+			i -= size
+			if _, err := m.Value.MarshalTo(dAtA[i:]); err != nil {
+				return 0, err
+			}
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *LogArgument_Int64Value) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *LogArgument_Int64Value) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	i = encodeVarintLogLineMessage(dAtA, i, uint64(m.Int64Value))
+	i--
+	dAtA[i] = 0x8
+	return len(dAtA) - i, nil
+}
+func (m *LogArgument_Uint64Value) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *LogArgument_Uint64Value) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	i = encodeVarintLogLineMessage(dAtA, i, uint64(m.Uint64Value))
+	i--
+	dAtA[i] = 0x10
+	return len(dAtA) - i, nil
+}
+func (m *LogArgument_DoubleValue) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *LogArgument_DoubleValue) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	i -= 8
+	encoding_binary.LittleEndian.PutUint64(dAtA[i:], uint64(math.Float64bits(float64(m.DoubleValue))))
+	i--
+	dAtA[i] = 0x19
+	return len(dAtA) - i, nil
+}
+func (m *LogArgument_BoolValue) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *LogArgument_BoolValue) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	i--
+	if m.BoolValue {
+		dAtA[i] = 1
+	} else {
+		dAtA[i] = 0
+	}
+	i--
+	dAtA[i] = 0x20
+	return len(dAtA) - i, nil
+}
+func (m *LogArgument_BytesValue) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *LogArgument_BytesValue) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.BytesValue != nil {
+		i -= len(m.BytesValue)
+		copy(dAtA[i:], m.BytesValue)
+		i = encodeVarintLogLineMessage(dAtA, i, uint64(len(m.BytesValue)))
+		i--
+		dAtA[i] = 0x2a
+	}
+	return len(dAtA) - i, nil
+}
+func (m *LogArgument_StringValue) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *LogArgument_StringValue) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	i -= len(m.StringValue)
+	copy(dAtA[i:], m.StringValue)
+	i = encodeVarintLogLineMessage(dAtA, i, uint64(len(m.StringValue)))
+	i--
+	dAtA[i] = 0x32
+	return len(dAtA) - i, nil
+}
+func (m *LogArgument_DurationValue) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *LogArgument_DurationValue) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	i = encodeVarintLogLineMessage(dAtA, i, uint64(m.DurationValue))
+	i--
+	dAtA[i] = 0x38
+	return len(dAtA) - i, nil
+}
+func (m *LogArgument_TimestampValue) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *LogArgument_TimestampValue) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	i = encodeVarintLogLineMessage(dAtA, i, uint64(m.TimestampValue))
+	i--
+	dAtA[i] = 0x40
+	return len(dAtA) - i, nil
+}
+func (m *LogCallerMessage) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *LogCallerMessage) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *LogCallerMessage) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Function) > 0 {
+		i -= len(m.Function)
+		copy(dAtA[i:], m.Function)
+		i = encodeVarintLogLineMessage(dAtA, i, uint64(len(m.Function)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.Line != 0 {
+		i = encodeVarintLogLineMessage(dAtA, i, uint64(m.Line))
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.File) > 0 {
+		i -= len(m.File)
+		copy(dAtA[i:], m.File)
+		i = encodeVarintLogLineMessage(dAtA, i, uint64(len(m.File)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func encodeVarintLogLineMessage(dAtA []byte, offset int, v uint64) int {
+	offset -= sovLogLineMessage(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return base
+}
+func (m *LogLineMessage) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Message)
+	if l > 0 {
+		n += 1 + l + sovLogLineMessage(uint64(l))
+	}
+	if m.LogLevel != 0 {
+		n += 1 + sovLogLineMessage(uint64(m.LogLevel))
+	}
+	if len(m.Args) > 0 {
+		for _, s := range m.Args {
+			l = len(s)
+			n += 1 + l + sovLogLineMessage(uint64(l))
+		}
+	}
+	if m.Timestamp != 0 {
+		n += 1 + sovLogLineMessage(uint64(m.Timestamp))
+	}
+	l = len(m.LoggerName)
+	if l > 0 {
+		n += 1 + l + sovLogLineMessage(uint64(l))
+	}
+	l = m.Correlation.Size()
+	n += 1 + l + sovLogLineMessage(uint64(l))
+	if len(m.TypedArgs) > 0 {
+		for _, e := range m.TypedArgs {
+			l = e.Size()
+			n += 1 + l + sovLogLineMessage(uint64(l))
+		}
+	}
+	l = m.Caller.Size()
+	n += 1 + l + sovLogLineMessage(uint64(l))
+	l = len(m.StackTrace)
+	if l > 0 {
+		n += 1 + l + sovLogLineMessage(uint64(l))
+	}
+	return n
+}
+
+func (m *LogCorrelationMessage) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Shard)
+	if l > 0 {
+		n += 1 + l + sovLogLineMessage(uint64(l))
+	}
+	if m.Epoch != 0 {
+		n += 1 + sovLogLineMessage(uint64(m.Epoch))
+	}
+	if m.Round != 0 {
+		n += 1 + sovLogLineMessage(uint64(m.Round))
+	}
+	l = len(m.SubRound)
+	if l > 0 {
+		n += 1 + l + sovLogLineMessage(uint64(l))
+	}
+	return n
+}
+
+func (m *LogArgument) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Value != nil {
+		n += m.Value.Size()
+	}
+	return n
+}
+
+func (m *LogArgument_Int64Value) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	n += 1 + sovLogLineMessage(uint64(m.Int64Value))
+	return n
+}
+func (m *LogArgument_Uint64Value) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	n += 1 + sovLogLineMessage(uint64(m.Uint64Value))
+	return n
+}
+func (m *LogArgument_DoubleValue) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	n += 9
+	return n
+}
+func (m *LogArgument_BoolValue) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	n += 2
+	return n
+}
+func (m *LogArgument_BytesValue) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.BytesValue != nil {
+		l = len(m.BytesValue)
+		n += 1 + l + sovLogLineMessage(uint64(l))
+	}
+	return n
+}
+func (m *LogArgument_StringValue) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.StringValue)
+	n += 1 + l + sovLogLineMessage(uint64(l))
+	return n
+}
+func (m *LogArgument_DurationValue) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	n += 1 + sovLogLineMessage(uint64(m.DurationValue))
+	return n
+}
+func (m *LogArgument_TimestampValue) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	n += 1 + sovLogLineMessage(uint64(m.TimestampValue))
+	return n
+}
+func (m *LogCallerMessage) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.File)
+	if l > 0 {
+		n += 1 + l + sovLogLineMessage(uint64(l))
+	}
+	if m.Line != 0 {
+		n += 1 + sovLogLineMessage(uint64(m.Line))
+	}
+	l = len(m.Function)
+	if l > 0 {
+		n += 1 + l + sovLogLineMessage(uint64(l))
+	}
+	return n
+}
+
+func sovLogLineMessage(x uint64) (n int) {
+	return (math_bits.Len64(x|1) + 6) / 7
+}
+func sozLogLineMessage(x uint64) (n int) {
+	return sovLogLineMessage(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (this *LogLineMessage) String() string {
+	if this == nil {
+		return "nil"
+	}
+	repeatedStringForTypedArgs := "[]LogArgument{"
+	for _, f := range this.TypedArgs {
+		repeatedStringForTypedArgs += strings.Replace(strings.Replace(f.String(), "LogArgument", "LogArgument", 1), `&`, ``, 1) + ","
+	}
+	repeatedStringForTypedArgs += "}"
+	s := strings.Join([]string{`&LogLineMessage{`,
+		`Message:` + fmt.Sprintf("%v", this.Message) + `,`,
+		`LogLevel:` + fmt.Sprintf("%v", this.LogLevel) + `,`,
+		`Args:` + fmt.Sprintf("%v", this.Args) + `,`,
+		`Timestamp:` + fmt.Sprintf("%v", this.Timestamp) + `,`,
+		`LoggerName:` + fmt.Sprintf("%v", this.LoggerName) + `,`,
+		`Correlation:` + strings.Replace(strings.Replace(this.Correlation.String(), "LogCorrelationMessage", "LogCorrelationMessage", 1), `&`, ``, 1) + `,`,
+		`TypedArgs:` + repeatedStringForTypedArgs + `,`,
+		`Caller:` + strings.Replace(strings.Replace(this.Caller.String(), "LogCallerMessage", "LogCallerMessage", 1), `&`, ``, 1) + `,`,
+		`StackTrace:` + fmt.Sprintf("%v", this.StackTrace) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *LogCorrelationMessage) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&LogCorrelationMessage{`,
+		`Shard:` + fmt.Sprintf("%v", this.Shard) + `,`,
+		`Epoch:` + fmt.Sprintf("%v", this.Epoch) + `,`,
+		`Round:` + fmt.Sprintf("%v", this.Round) + `,`,
+		`SubRound:` + fmt.Sprintf("%v", this.SubRound) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *LogArgument) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&LogArgument{`,
+		`Value:` + fmt.Sprintf("%v", this.Value) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *LogArgument_Int64Value) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&LogArgument_Int64Value{`,
+		`Int64Value:` + fmt.Sprintf("%v", this.Int64Value) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *LogArgument_Uint64Value) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&LogArgument_Uint64Value{`,
+		`Uint64Value:` + fmt.Sprintf("%v", this.Uint64Value) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *LogArgument_DoubleValue) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&LogArgument_DoubleValue{`,
+		`DoubleValue:` + fmt.Sprintf("%v", this.DoubleValue) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *LogArgument_BoolValue) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&LogArgument_BoolValue{`,
+		`BoolValue:` + fmt.Sprintf("%v", this.BoolValue) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *LogArgument_BytesValue) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&LogArgument_BytesValue{`,
+		`BytesValue:` + fmt.Sprintf("%v", this.BytesValue) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *LogArgument_StringValue) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&LogArgument_StringValue{`,
+		`StringValue:` + fmt.Sprintf("%v", this.StringValue) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *LogArgument_DurationValue) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&LogArgument_DurationValue{`,
+		`DurationValue:` + fmt.Sprintf("%v", this.DurationValue) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *LogArgument_TimestampValue) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&LogArgument_TimestampValue{`,
+		`TimestampValue:` + fmt.Sprintf("%v", this.TimestampValue) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *LogCallerMessage) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&LogCallerMessage{`,
+		`File:` + fmt.Sprintf("%v", this.File) + `,`,
+		`Line:` + fmt.Sprintf("%v", this.Line) + `,`,
+		`Function:` + fmt.Sprintf("%v", this.Function) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func valueToStringLogLineMessage(v interface{}) string {
+	rv := reflect.ValueOf(v)
+	if rv.IsNil() {
+		return "nil"
+	}
+	pv := reflect.Indirect(rv).Interface()
+	return fmt.Sprintf("*%v", pv)
+}
+func (m *LogLineMessage) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowLogLineMessage
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: LogLineMessage: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: LogLineMessage: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Message", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLogLineMessage
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthLogLineMessage
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthLogLineMessage
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Message = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LogLevel", wireType)
+			}
+			m.LogLevel = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLogLineMessage
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.LogLevel |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Args", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLogLineMessage
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthLogLineMessage
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthLogLineMessage
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Args = append(m.Args, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Timestamp", wireType)
+			}
+			m.Timestamp = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLogLineMessage
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Timestamp |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LoggerName", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLogLineMessage
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthLogLineMessage
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthLogLineMessage
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.LoggerName = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Correlation", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLogLineMessage
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthLogLineMessage
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthLogLineMessage
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.Correlation.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TypedArgs", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLogLineMessage
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthLogLineMessage
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthLogLineMessage
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.TypedArgs = append(m.TypedArgs, LogArgument{})
+			if err := m.TypedArgs[len(m.TypedArgs)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Caller", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLogLineMessage
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthLogLineMessage
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthLogLineMessage
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.Caller.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 9:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field StackTrace", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLogLineMessage
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthLogLineMessage
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthLogLineMessage
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.StackTrace = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipLogLineMessage(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthLogLineMessage
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
 	}
-	return n
-}
 
-func sovLogLineMessage(x uint64) (n int) {
-	return (math_bits.Len64(x|1) + 6) / 7
-}
-func sozLogLineMessage(x uint64) (n int) {
-	return sovLogLineMessage(uint64((x << 1) ^ uint64((int64(x) >> 63))))
-}
-func (this *LogLineMessage) String() string {
-	if this == nil {
-		return "nil"
-	}
-	s := strings.Join([]string{`&LogLineMessage{`,
-		`Message:` + fmt.Sprintf("%v", this.Message) + `,`,
-		`LogLevel:` + fmt.Sprintf("%v", this.LogLevel) + `,`,
-		`Args:` + fmt.Sprintf("%v", this.Args) + `,`,
-		`Timestamp:` + fmt.Sprintf("%v", this.Timestamp) + `,`,
-		`LoggerName:` + fmt.Sprintf("%v", this.LoggerName) + `,`,
-		`Correlation:` + strings.Replace(strings.Replace(this.Correlation.String(), "LogCorrelationMessage", "LogCorrelationMessage", 1), `&`, ``, 1) + `,`,
-		`}`,
-	}, "")
-	return s
-}
-func (this *LogCorrelationMessage) String() string {
-	if this == nil {
-		return "nil"
-	}
-	s := strings.Join([]string{`&LogCorrelationMessage{`,
-		`Shard:` + fmt.Sprintf("%v", this.Shard) + `,`,
-		`Epoch:` + fmt.Sprintf("%v", this.Epoch) + `,`,
-		`Round:` + fmt.Sprintf("%v", this.Round) + `,`,
-		`SubRound:` + fmt.Sprintf("%v", this.SubRound) + `,`,
-		`}`,
-	}, "")
-	return s
-}
-func valueToStringLogLineMessage(v interface{}) string {
-	rv := reflect.ValueOf(v)
-	if rv.IsNil() {
-		return "nil"
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
 	}
-	pv := reflect.Indirect(rv).Interface()
-	return fmt.Sprintf("*%v", pv)
+	return nil
 }
-func (m *LogLineMessage) Unmarshal(dAtA []byte) error {
+func (m *LogCorrelationMessage) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -556,15 +1934,15 @@ func (m *LogLineMessage) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: LogLineMessage: wiretype end group for non-group")
+			return fmt.Errorf("proto: LogCorrelationMessage: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: LogLineMessage: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: LogCorrelationMessage: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Message", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Shard", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -592,13 +1970,13 @@ func (m *LogLineMessage) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Message = string(dAtA[iNdEx:postIndex])
+			m.Shard = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 2:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field LogLevel", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Epoch", wireType)
 			}
-			m.LogLevel = 0
+			m.Epoch = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowLogLineMessage
@@ -608,14 +1986,33 @@ func (m *LogLineMessage) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.LogLevel |= int32(b&0x7F) << shift
+				m.Epoch |= uint32(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
 		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Round", wireType)
+			}
+			m.Round = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLogLineMessage
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Round |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Args", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field SubRound", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -643,13 +2040,114 @@ func (m *LogLineMessage) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Args = append(m.Args, string(dAtA[iNdEx:postIndex]))
+			m.SubRound = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipLogLineMessage(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthLogLineMessage
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *LogArgument) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowLogLineMessage
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: LogArgument: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: LogArgument: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Int64Value", wireType)
+			}
+			var v int64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLogLineMessage
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Value = &LogArgument_Int64Value{v}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Uint64Value", wireType)
+			}
+			var v uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLogLineMessage
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Value = &LogArgument_Uint64Value{v}
+		case 3:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DoubleValue", wireType)
+			}
+			var v uint64
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			v = uint64(encoding_binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+			m.Value = &LogArgument_DoubleValue{float64(math.Float64frombits(v))}
 		case 4:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Timestamp", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field BoolValue", wireType)
 			}
-			m.Timestamp = 0
+			var v int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowLogLineMessage
@@ -659,16 +2157,18 @@ func (m *LogLineMessage) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Timestamp |= int64(b&0x7F) << shift
+				v |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			b := bool(v != 0)
+			m.Value = &LogArgument_BoolValue{b}
 		case 5:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field LoggerName", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field BytesValue", wireType)
 			}
-			var stringLen uint64
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowLogLineMessage
@@ -678,29 +2178,30 @@ func (m *LogLineMessage) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if byteLen < 0 {
 				return ErrInvalidLengthLogLineMessage
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + byteLen
 			if postIndex < 0 {
 				return ErrInvalidLengthLogLineMessage
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.LoggerName = string(dAtA[iNdEx:postIndex])
+			v := make([]byte, postIndex-iNdEx)
+			copy(v, dAtA[iNdEx:postIndex])
+			m.Value = &LogArgument_BytesValue{v}
 			iNdEx = postIndex
 		case 6:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Correlation", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field StringValue", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowLogLineMessage
@@ -710,25 +2211,64 @@ func (m *LogLineMessage) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthLogLineMessage
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthLogLineMessage
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.Correlation.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.Value = &LogArgument_StringValue{string(dAtA[iNdEx:postIndex])}
 			iNdEx = postIndex
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DurationValue", wireType)
+			}
+			var v int64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLogLineMessage
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Value = &LogArgument_DurationValue{v}
+		case 8:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TimestampValue", wireType)
+			}
+			var v int64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLogLineMessage
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Value = &LogArgument_TimestampValue{v}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipLogLineMessage(dAtA[iNdEx:])
@@ -750,7 +2290,7 @@ func (m *LogLineMessage) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *LogCorrelationMessage) Unmarshal(dAtA []byte) error {
+func (m *LogCallerMessage) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -773,15 +2313,15 @@ func (m *LogCorrelationMessage) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: LogCorrelationMessage: wiretype end group for non-group")
+			return fmt.Errorf("proto: LogCallerMessage: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: LogCorrelationMessage: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: LogCallerMessage: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Shard", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field File", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -809,13 +2349,13 @@ func (m *LogCorrelationMessage) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Shard = string(dAtA[iNdEx:postIndex])
+			m.File = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 2:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Epoch", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Line", wireType)
 			}
-			m.Epoch = 0
+			m.Line = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowLogLineMessage
@@ -825,33 +2365,14 @@ func (m *LogCorrelationMessage) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Epoch |= uint32(b&0x7F) << shift
+				m.Line |= int32(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
 		case 3:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Round", wireType)
-			}
-			m.Round = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowLogLineMessage
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.Round |= int64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-		case 4:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field SubRound", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Function", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -879,7 +2400,7 @@ func (m *LogCorrelationMessage) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.SubRound = string(dAtA[iNdEx:postIndex])
+			m.Function = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
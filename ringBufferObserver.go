@@ -0,0 +1,240 @@
+package logger
+
+import (
+	"io"
+	"os"
+	"os/signal"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/Dharitri-org/me-core-logger-go/proto"
+	"github.com/Dharitri-org/me-core/core/check"
+)
+
+const ringBufferLoggerName = "logger/ringbuffer"
+const ringBufferDumpBeginMessage = "ring buffer dump begin"
+const ringBufferDumpEndMessage = "ring buffer dump end"
+
+const (
+	dumpReasonRequested = "requested"
+	dumpReasonError     = "error"
+	dumpReasonPanic     = "panic"
+	dumpReasonSignal    = "signal"
+)
+
+var _ LogLineObserver = (*RingBufferObserver)(nil)
+
+// ArgsRingBufferObserver is the argument used to create a new RingBufferObserver. At least one of the MaxLines and
+// MaxBytes limits should be set, the size of a log line being the total length of its strings. The automatic dumps
+// are written on the DumpWriter, formatted by the Formatter
+type ArgsRingBufferObserver struct {
+	DumpWriter  io.Writer
+	Formatter   Formatter
+	MaxLines    int
+	MaxBytes    int
+	DumpOnError bool
+}
+
+// RingBufferObserver keeps the last log lines in memory, so the detailed log lines (such as TRACE and DEBUG) can be
+// available after a crash without writing them all the time. It should be added with a lower minimum level than
+// the ones of the other observers, the loggers' levels allowing the detailed log lines.
+// The retained log lines can be dumped on any writer by calling Dump. They are also dumped automatically on the
+// dump writer, then discarded, when a log line of at least the ERROR level is received (if DumpOnError is set),
+// when a go routine deferring DumpOnPanic panics or when one of the signals provided to DumpOnSignal is received.
+// Each dump is enclosed by two log lines of the "logger/ringbuffer" logger, holding the reason of the dump.
+// The RingBufferObserver should be added with RegisterLogLineObserver(ringBuffer, ObserverOptions{MinLevel: LogTrace}).
+type RingBufferObserver struct {
+	mut         sync.Mutex
+	dumpWriter  io.Writer
+	formatter   Formatter
+	maxLines    int
+	maxBytes    int
+	dumpOnError bool
+	lines       []*LogLineWrapper
+	numBytes    int
+}
+
+// NewRingBufferObserver creates a new RingBufferObserver
+func NewRingBufferObserver(args ArgsRingBufferObserver) (*RingBufferObserver, error) {
+	if args.DumpWriter == nil {
+		return nil, ErrNilWriter
+	}
+	if check.IfNil(args.Formatter) {
+		return nil, ErrNilFormatter
+	}
+	if args.MaxLines < 0 || args.MaxBytes < 0 || args.MaxLines+args.MaxBytes == 0 {
+		return nil, ErrInvalidRingBufferSize
+	}
+
+	return &RingBufferObserver{
+		dumpWriter:  args.DumpWriter,
+		formatter:   args.Formatter,
+		maxLines:    args.MaxLines,
+		maxBytes:    args.MaxBytes,
+		dumpOnError: args.DumpOnError,
+		lines:       make([]*LogLineWrapper, 0),
+	}, nil
+}
+
+// OutputLogLine retains a copy of the log line, discarding the oldest retained log lines if the limits are exceeded.
+// The error of the automatic dump triggered by the log line, if any, is returned
+func (rbo *RingBufferObserver) OutputLogLine(line LogLineHandler) error {
+	if check.IfNil(line) {
+		return nil
+	}
+
+	rbo.mut.Lock()
+	defer rbo.mut.Unlock()
+
+	rbo.retain(CloneLogLine(line))
+	if rbo.dumpOnError && LogLevel(line.GetLogLevel()) >= LogError {
+		return rbo.dumpAndReset(dumpReasonError)
+	}
+
+	return nil
+}
+
+func (rbo *RingBufferObserver) retain(line *LogLineWrapper) {
+	rbo.lines = append(rbo.lines, line)
+	rbo.numBytes += logLineSize(line)
+
+	for len(rbo.lines) > 1 && rbo.isOverLimits() {
+		rbo.numBytes -= logLineSize(rbo.lines[0])
+		rbo.lines[0] = nil
+		rbo.lines = rbo.lines[1:]
+	}
+}
+
+func (rbo *RingBufferObserver) isOverLimits() bool {
+	if rbo.maxLines > 0 && len(rbo.lines) > rbo.maxLines {
+		return true
+	}
+
+	return rbo.maxBytes > 0 && rbo.numBytes > rbo.maxBytes
+}
+
+func logLineSize(line *LogLineWrapper) int {
+	size := len(line.LoggerName) + len(line.Message) + len(line.Caller.File) + len(line.Caller.Function)
+	size += len(line.StackTrace)
+	for _, arg := range line.Args {
+		size += len(arg)
+	}
+
+	return size
+}
+
+// Dump writes the retained log lines on the provided writer, from the oldest to the newest. The log lines are kept
+func (rbo *RingBufferObserver) Dump(w io.Writer) error {
+	if w == nil {
+		return ErrNilWriter
+	}
+
+	rbo.mut.Lock()
+	defer rbo.mut.Unlock()
+
+	return rbo.dump(w, dumpReasonRequested)
+}
+
+// DumpOnPanic dumps the retained log lines on the dump writer if the calling go routine panics, then panics again
+// with the same value. It should be deferred directly: defer ringBuffer.DumpOnPanic()
+func (rbo *RingBufferObserver) DumpOnPanic() {
+	recovered := recover()
+	if recovered == nil {
+		return
+	}
+
+	rbo.mut.Lock()
+	_ = rbo.dumpAndReset(dumpReasonPanic)
+	rbo.mut.Unlock()
+
+	panic(recovered)
+}
+
+// DumpOnSignal dumps the retained log lines on the dump writer each time one of the provided signals, such as
+// SIGUSR1, is received. The returned function stops the dumps on signals
+func (rbo *RingBufferObserver) DumpOnSignal(signals ...os.Signal) (func(), error) {
+	if len(signals) == 0 {
+		return nil, ErrMissingDumpSignals
+	}
+
+	chSignals := make(chan os.Signal, 1)
+	chStop := make(chan struct{})
+	signal.Notify(chSignals, signals...)
+
+	go func() {
+		for {
+			select {
+			case <-chSignals:
+				rbo.mut.Lock()
+				_ = rbo.dumpAndReset(dumpReasonSignal)
+				rbo.mut.Unlock()
+			case <-chStop:
+				return
+			}
+		}
+	}()
+
+	once := sync.Once{}
+	stop := func() {
+		once.Do(func() {
+			signal.Stop(chSignals)
+			close(chStop)
+		})
+	}
+
+	return stop, nil
+}
+
+// NumLines returns the number of retained log lines
+func (rbo *RingBufferObserver) NumLines() int {
+	rbo.mut.Lock()
+	defer rbo.mut.Unlock()
+
+	return len(rbo.lines)
+}
+
+func (rbo *RingBufferObserver) dumpAndReset(reason string) error {
+	err := rbo.dump(rbo.dumpWriter, reason)
+	rbo.lines = make([]*LogLineWrapper, 0)
+	rbo.numBytes = 0
+
+	return err
+}
+
+func (rbo *RingBufferObserver) dump(w io.Writer, reason string) error {
+	numLines := strconv.Itoa(len(rbo.lines))
+	_, err := w.Write(rbo.formatter.Output(newRingBufferDumpLine(ringBufferDumpBeginMessage, reason, numLines)))
+	if err != nil {
+		return err
+	}
+
+	for _, line := range rbo.lines {
+		_, err = w.Write(rbo.formatter.Output(line))
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err = w.Write(rbo.formatter.Output(newRingBufferDumpLine(ringBufferDumpEndMessage, reason, numLines)))
+
+	return err
+}
+
+func newRingBufferDumpLine(message string, reason string, numLines string) *LogLineWrapper {
+	return &LogLineWrapper{
+		LogLineMessage: proto.LogLineMessage{
+			LoggerName:  ringBufferLoggerName,
+			Correlation: GetCorrelation(),
+			Message:     message,
+			LogLevel:    int32(LogInfo),
+			Args:        []string{"reason", reason, "num lines", numLines},
+			Timestamp:   time.Now().UnixNano(),
+		},
+	}
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (rbo *RingBufferObserver) IsInterfaceNil() bool {
+	return rbo == nil
+}
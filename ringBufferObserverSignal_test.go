@@ -0,0 +1,44 @@
+//go:build linux || darwin
+
+package logger_test
+
+import (
+	"os"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	logger "github.com/Dharitri-org/me-core-logger-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRingBufferObserver_ShouldDumpOnSignal(t *testing.T) {
+	dumpWriter := &syncBuffer{}
+	ringBuffer, err := logger.NewRingBufferObserver(logger.ArgsRingBufferObserver{
+		DumpWriter: dumpWriter,
+		Formatter:  createPatternFormatter(t, lineObserverTestTemplate),
+		MaxLines:   100,
+	})
+	require.Nil(t, err)
+	log := logger.NewLogger("node", logger.LogTrace, createLineObserverTestOutput(t, ringBuffer))
+	stop, err := ringBuffer.DumpOnSignal(syscall.SIGUSR1)
+	require.Nil(t, err)
+	defer stop()
+
+	log.Debug("before signal")
+	process, err := os.FindProcess(os.Getpid())
+	require.Nil(t, err)
+	err = process.Signal(syscall.SIGUSR1)
+	require.Nil(t, err)
+
+	assert.Eventually(t, func() bool {
+		return strings.Contains(dumpWriter.String(), "ring buffer dump end reason = signal num lines = 1")
+	}, time.Second, time.Millisecond)
+	assert.Contains(t, dumpWriter.String(), "DEBUG node before signal")
+	assert.Equal(t, 0, ringBuffer.NumLines())
+
+	stop()
+	stop()
+}
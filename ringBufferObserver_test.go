@@ -0,0 +1,183 @@
+package logger_test
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	logger "github.com/Dharitri-org/me-core-logger-go"
+	"github.com/Dharitri-org/me-core-logger-go/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewRingBufferObserver_InvalidArgsShouldErr(t *testing.T) {
+	t.Parallel()
+
+	ringBuffer, err := logger.NewRingBufferObserver(logger.ArgsRingBufferObserver{Formatter: &mock.FormatterStub{}, MaxLines: 1})
+	assert.Nil(t, ringBuffer)
+	assert.Equal(t, logger.ErrNilWriter, err)
+
+	ringBuffer, err = logger.NewRingBufferObserver(logger.ArgsRingBufferObserver{DumpWriter: &bytes.Buffer{}, MaxLines: 1})
+	assert.Nil(t, ringBuffer)
+	assert.Equal(t, logger.ErrNilFormatter, err)
+
+	for _, limits := range [][2]int{{0, 0}, {-1, 10}, {10, -1}} {
+		ringBuffer, err = logger.NewRingBufferObserver(logger.ArgsRingBufferObserver{
+			DumpWriter: &bytes.Buffer{},
+			Formatter:  &mock.FormatterStub{},
+			MaxLines:   limits[0],
+			MaxBytes:   limits[1],
+		})
+		assert.Nil(t, ringBuffer)
+		assert.True(t, errors.Is(err, logger.ErrInvalidRingBufferSize))
+	}
+}
+
+func TestRingBufferObserver_ShouldKeepTheLastLines(t *testing.T) {
+	t.Parallel()
+
+	ringBuffer, err := logger.NewRingBufferObserver(logger.ArgsRingBufferObserver{
+		DumpWriter: &bytes.Buffer{},
+		Formatter:  createPatternFormatter(t, lineObserverTestTemplate),
+		MaxLines:   3,
+	})
+	require.Nil(t, err)
+	log := logger.NewLogger("node", logger.LogTrace, createLineObserverTestOutput(t, ringBuffer))
+
+	log.Trace("processing", "nonce", 1)
+	log.Debug("processing", "nonce", 2)
+	log.Trace("processing", "nonce", 3)
+	log.Info("processed", "nonce", 3)
+	assert.Equal(t, 3, ringBuffer.NumLines())
+
+	dump := &bytes.Buffer{}
+	err = ringBuffer.Dump(dump)
+	require.Nil(t, err)
+	expected := []string{
+		"INFO logger/ringbuffer ring buffer dump begin reason = requested num lines = 3 ",
+		"DEBUG node processing nonce = 2 ",
+		"TRACE node processing nonce = 3 ",
+		"INFO node processed nonce = 3 ",
+		"INFO logger/ringbuffer ring buffer dump end reason = requested num lines = 3 ",
+	}
+	assert.Equal(t, expected, splitLines(dump.String()))
+	assert.Equal(t, 3, ringBuffer.NumLines())
+	assert.Equal(t, logger.ErrNilWriter, ringBuffer.Dump(nil))
+}
+
+func TestRingBufferObserver_ShouldLimitTheRetainedBytes(t *testing.T) {
+	t.Parallel()
+
+	ringBuffer, err := logger.NewRingBufferObserver(logger.ArgsRingBufferObserver{
+		DumpWriter: &bytes.Buffer{},
+		Formatter:  createPatternFormatter(t, lineObserverTestTemplate),
+		MaxBytes:   45,
+	})
+	require.Nil(t, err)
+	log := logger.NewLogger("node", logger.LogTrace, createLineObserverTestOutput(t, ringBuffer))
+
+	log.Trace("message 1", "key", "value")
+	log.Trace("message 2", "key", "value")
+	log.Trace("message 3", "key", "value")
+	assert.Equal(t, 2, ringBuffer.NumLines())
+
+	log.Trace(strings.Repeat("long message ", 10))
+	assert.Equal(t, 1, ringBuffer.NumLines())
+}
+
+func TestRingBufferObserver_ShouldDumpOnError(t *testing.T) {
+	t.Parallel()
+
+	dumpWriter := &syncBuffer{}
+	ringBuffer, err := logger.NewRingBufferObserver(logger.ArgsRingBufferObserver{
+		DumpWriter:  dumpWriter,
+		Formatter:   createPatternFormatter(t, lineObserverTestTemplate),
+		MaxLines:    100,
+		DumpOnError: true,
+	})
+	require.Nil(t, err)
+	log := logger.NewLogger("node", logger.LogTrace, createLineObserverTestOutput(t, ringBuffer))
+
+	log.Trace("requesting", "peer", "p1")
+	log.Warn("request timeout", "peer", "p1")
+	assert.Equal(t, "", dumpWriter.String())
+
+	log.Error("sync failed", "peer", "p1")
+	expected := []string{
+		"INFO logger/ringbuffer ring buffer dump begin reason = error num lines = 3 ",
+		"TRACE node requesting peer = p1 ",
+		"WARN node request timeout peer = p1 ",
+		"ERROR node sync failed peer = p1 ",
+		"INFO logger/ringbuffer ring buffer dump end reason = error num lines = 3 ",
+	}
+	assert.Equal(t, expected, splitLines(dumpWriter.String()))
+	assert.Equal(t, 0, ringBuffer.NumLines())
+}
+
+func TestRingBufferObserver_DumpOnErrorFailureShouldBeReturned(t *testing.T) {
+	t.Parallel()
+
+	expectedErr := errors.New("expected error")
+	ringBuffer, err := logger.NewRingBufferObserver(logger.ArgsRingBufferObserver{
+		DumpWriter: &mock.WriterStub{
+			WriteCalled: func(p []byte) (n int, err error) {
+				return 0, expectedErr
+			},
+		},
+		Formatter:   createPatternFormatter(t, "%msg\n"),
+		MaxLines:    100,
+		DumpOnError: true,
+	})
+	require.Nil(t, err)
+
+	assert.Nil(t, ringBuffer.OutputLogLine(createPatternTestLogLine("node", "requesting", logger.LogTrace)))
+	err = ringBuffer.OutputLogLine(createPatternTestLogLine("node", "sync failed", logger.LogError))
+	assert.Equal(t, expectedErr, err)
+	assert.Equal(t, 0, ringBuffer.NumLines())
+}
+
+func TestRingBufferObserver_DumpOnPanicShouldDumpAndPanicAgain(t *testing.T) {
+	t.Parallel()
+
+	dumpWriter := &syncBuffer{}
+	ringBuffer, err := logger.NewRingBufferObserver(logger.ArgsRingBufferObserver{
+		DumpWriter: dumpWriter,
+		Formatter:  createPatternFormatter(t, lineObserverTestTemplate),
+		MaxLines:   100,
+	})
+	require.Nil(t, err)
+	log := logger.NewLogger("node", logger.LogTrace, createLineObserverTestOutput(t, ringBuffer))
+
+	assert.NotPanics(t, func() {
+		defer ringBuffer.DumpOnPanic()
+		log.Debug("no panic")
+	})
+	assert.Equal(t, "", dumpWriter.String())
+
+	assert.PanicsWithValue(t, "index out of range", func() {
+		defer ringBuffer.DumpOnPanic()
+		log.Debug("about to panic")
+		panic("index out of range")
+	})
+	lines := splitLines(dumpWriter.String())
+	require.Equal(t, 4, len(lines))
+	assert.Equal(t, "INFO logger/ringbuffer ring buffer dump begin reason = panic num lines = 2 ", lines[0])
+	assert.Equal(t, "DEBUG node about to panic ", lines[2])
+}
+
+func TestRingBufferObserver_DumpOnSignalWithoutSignalsShouldErr(t *testing.T) {
+	t.Parallel()
+
+	ringBuffer, err := logger.NewRingBufferObserver(logger.ArgsRingBufferObserver{
+		DumpWriter: &bytes.Buffer{},
+		Formatter:  createPatternFormatter(t, lineObserverTestTemplate),
+		MaxLines:   100,
+	})
+	require.Nil(t, err)
+
+	stop, err := ringBuffer.DumpOnSignal()
+	assert.Nil(t, stop)
+	assert.Equal(t, logger.ErrMissingDumpSignals, err)
+}
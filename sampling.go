@@ -0,0 +1,476 @@
+package logger
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	samplingParamsSeparator   = "|"
+	samplingValueSeparator    = "="
+	samplingParamFirst        = "first"
+	samplingParamThereafter   = "thereafter"
+	samplingParamRate         = "rate"
+	samplingParamBurst        = "burst"
+	samplingParamTick         = "tick"
+	samplingParamLevel        = "level"
+	samplingOff               = "off"
+	defaultSamplingTick       = time.Second
+	samplingSummaryMessage    = "log lines suppressed by sampling"
+	samplingSummaryKeyMessage = "message"
+	samplingSummaryKeyCount   = "suppressed"
+	samplingSummaryKeyTick    = "interval"
+)
+
+// maxSampledMessages is the maximum number of distinct messages tracked by a logger's sampler in a tick interval,
+// the messages exceeding it not being sampled
+const maxSampledMessages = 1024
+
+var errMissingSamplingParams = errors.New("missing ':' separator between the matching string and the sampling parameters")
+var errMissingSamplingMode = errors.New("either the first or the rate parameter is required")
+var errUnknownSamplingParam = errors.New("unknown parameter")
+var errUnsampledLevel = errors.New("only the levels up to ERROR can be sampled")
+var errMixedSamplingModes = errors.New("the first/thereafter and the rate/burst parameters can not be combined")
+
+var globalSampling samplingSettings
+
+// samplingSettings holds the last set sampling pattern along with the channel stopping the go routine that outputs
+// the sampling summaries
+type samplingSettings struct {
+	mut          sync.RWMutex
+	pattern      string
+	rules        []samplingRule
+	chStopTicker chan struct{}
+}
+
+// samplingRule holds one MATCHING_STRING:SAMPLING_PARAMETERS pair as parsed from a sampling pattern.
+// A nil config disables the sampling
+type samplingRule struct {
+	matcher *loggerNameMatcher
+	config  *samplingConfig
+}
+
+// samplingConfig holds the sampling parameters of a logger
+type samplingConfig struct {
+	first      uint64
+	thereafter uint64
+	rate       float64
+	burst      float64
+	tick       time.Duration
+	maxLevel   LogLevel
+}
+
+// SetSamplingPattern sets the sampling of the log lines produced by the loggers matched by the provided pattern.
+// The expected format is "MATCHING_STRING1:SAMPLING_PARAMETERS1,MATCHING_STRING2:SAMPLING_PARAMETERS2", the
+// matching strings having the syntax used by SetLogLevel. The sampling parameters are '|' separated name=value
+// pairs describing one of the following modes:
+//   - "first=N|thereafter=M": in each tick interval, the first N occurrences of a message are output, then every
+//     M-th occurrence (none if M is 0 or missing)
+//   - "rate=R|burst=B": a token bucket per message, refilled with R tokens per second and holding at most B tokens
+//     (defaults to R, at least 1)
+//
+// Both modes accept the optional "tick" parameter, the interval as a go duration (defaults to 1s) and the optional
+// "level" parameter, the highest log level being sampled (defaults to ERROR). The "off" value disables the sampling.
+// For example, "p2p/antiflood:first=10|thereafter=100,process/interceptors/**:rate=50|level=DEBUG" samples all
+// the lines of the antiflood logger and the TRACE and DEBUG lines of the interceptors subtree.
+// A message is identified by its log level and its text. At the end of each tick interval, a summary line is output
+// for each message that had suppressed occurrences, holding the message, the number of suppressed occurrences and
+// the elapsed interval. The summaries are output before the next line of the logger or by a background go routine
+// running while there is a sampling rule, whichever comes first. The pending summaries are also output by Shutdown
+// and before the Panic and Fatal exits. The rules are applied from left to right, the last matching rule deciding
+// the logger's sampling. When the pattern changes, the loggers whose sampling parameters change get new samplers,
+// the pending summaries of the replaced ones being output first. An empty pattern disables the sampling for all
+// loggers.
+func SetSamplingPattern(pattern string) error {
+	rules, err := parseSamplingPattern(pattern)
+	if err != nil {
+		return err
+	}
+
+	globalSampling.mut.Lock()
+	globalSampling.pattern = pattern
+	globalSampling.rules = rules
+	globalSampling.restartSummariesTicker()
+	globalSampling.mut.Unlock()
+
+	replaced := make(map[*logger]*messageSampler)
+	logMut.RLock()
+	for name, log := range loggers {
+		previous := log.replaceSampler(samplingConfigFor(rules, name))
+		if previous != nil {
+			replaced[log] = previous
+		}
+	}
+	logMut.RUnlock()
+
+	for log, sampler := range replaced {
+		log.outputPendingSamplingSummaries(sampler, true)
+	}
+
+	return nil
+}
+
+// GetSamplingPattern returns the last set sampling pattern
+func GetSamplingPattern() string {
+	globalSampling.mut.RLock()
+	defer globalSampling.mut.RUnlock()
+
+	return globalSampling.pattern
+}
+
+// samplerFor returns a new sampler for the provided logger name or nil if its log lines are not sampled
+func samplerFor(loggerName string) *messageSampler {
+	globalSampling.mut.RLock()
+	defer globalSampling.mut.RUnlock()
+
+	return newMessageSampler(samplingConfigFor(globalSampling.rules, loggerName))
+}
+
+// restartSummariesTicker restarts the go routine outputting the summaries of the ended tick intervals, so they are
+// output even if the loggers stop logging. The go routine runs while there is a sampling rule, checking the samplers
+// at the shortest tick interval. It should be called under the settings mutex
+func (ss *samplingSettings) restartSummariesTicker() {
+	if ss.chStopTicker != nil {
+		close(ss.chStopTicker)
+		ss.chStopTicker = nil
+	}
+
+	period := shortestSamplingTick(ss.rules)
+	if period == 0 {
+		return
+	}
+
+	ss.chStopTicker = make(chan struct{})
+	go outputSamplingSummariesPeriodically(period, ss.chStopTicker)
+}
+
+func shortestSamplingTick(rules []samplingRule) time.Duration {
+	shortest := time.Duration(0)
+	for _, rule := range rules {
+		if rule.config == nil {
+			continue
+		}
+		if shortest == 0 || rule.config.tick < shortest {
+			shortest = rule.config.tick
+		}
+	}
+
+	return shortest
+}
+
+func outputSamplingSummariesPeriodically(period time.Duration, chStop chan struct{}) {
+	ticker := time.NewTicker(period)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			outputAllSamplingSummaries(false)
+		case <-chStop:
+			return
+		}
+	}
+}
+
+// outputAllSamplingSummaries outputs the summaries of the ended tick intervals of all the loggers' samplers or, if
+// force is set, the summaries of their current tick intervals
+func outputAllSamplingSummaries(force bool) {
+	logMut.RLock()
+	allLoggers := make([]*logger, 0, len(loggers))
+	for _, log := range loggers {
+		allLoggers = append(allLoggers, log)
+	}
+	logMut.RUnlock()
+
+	for _, log := range allLoggers {
+		log.outputPendingSamplingSummaries(log.getSampler(), force)
+	}
+}
+
+func samplingConfigFor(rules []samplingRule, loggerName string) *samplingConfig {
+	var config *samplingConfig
+	for _, rule := range rules {
+		if rule.matcher.isMatching(loggerName) {
+			config = rule.config
+		}
+	}
+
+	return config
+}
+
+func parseSamplingPattern(pattern string) ([]samplingRule, error) {
+	if len(pattern) == 0 {
+		return make([]samplingRule, 0), nil
+	}
+
+	splitRules := strings.Split(pattern, ",")
+	rules := make([]samplingRule, len(splitRules))
+	for i, ruleString := range splitRules {
+		rule, err := parseSamplingRule(ruleString)
+		if err != nil {
+			return nil, fmt.Errorf("%w: rule %d '%s': %s", ErrInvalidSamplingPattern, i+1, ruleString, err.Error())
+		}
+
+		rules[i] = rule
+	}
+
+	return rules, nil
+}
+
+func parseSamplingRule(ruleString string) (samplingRule, error) {
+	// the last separator is considered as the matching string might be a regular expression containing ':'
+	separatorIndex := strings.LastIndex(ruleString, ":")
+	if separatorIndex < 0 {
+		return samplingRule{}, errMissingSamplingParams
+	}
+
+	pattern := ruleString[:separatorIndex]
+	matcher, err := newLoggerNameMatcher(pattern)
+	if err != nil {
+		return samplingRule{}, fmt.Errorf("invalid matching string '%s': %w", pattern, err)
+	}
+
+	config, err := parseSamplingConfig(ruleString[separatorIndex+1:])
+	if err != nil {
+		return samplingRule{}, err
+	}
+
+	return samplingRule{
+		matcher: matcher,
+		config:  config,
+	}, nil
+}
+
+func parseSamplingConfig(params string) (*samplingConfig, error) {
+	if params == samplingOff {
+		return nil, nil
+	}
+
+	config := &samplingConfig{
+		tick:     defaultSamplingTick,
+		maxLevel: LogError,
+	}
+	hasFirst, hasBurst := false, false
+	for _, param := range strings.Split(params, samplingParamsSeparator) {
+		name, value, found := strings.Cut(param, samplingValueSeparator)
+		if !found {
+			return nil, fmt.Errorf("missing value for parameter '%s'", param)
+		}
+
+		var err error
+		switch name {
+		case samplingParamFirst:
+			config.first, err = strconv.ParseUint(value, 10, 64)
+			hasFirst = true
+		case samplingParamThereafter:
+			config.thereafter, err = strconv.ParseUint(value, 10, 64)
+			hasFirst = true
+		case samplingParamRate:
+			config.rate, err = parsePositiveFloat(value)
+		case samplingParamBurst:
+			config.burst, err = parsePositiveFloat(value)
+			hasBurst = true
+		case samplingParamTick:
+			config.tick, err = time.ParseDuration(value)
+			if err == nil && config.tick <= 0 {
+				err = fmt.Errorf("non-positive duration %s", value)
+			}
+		case samplingParamLevel:
+			config.maxLevel, err = GetLogLevel(value)
+			if err == nil && config.maxLevel > LogError {
+				err = errUnsampledLevel
+			}
+		default:
+			err = errUnknownSamplingParam
+		}
+		if err != nil {
+			return nil, fmt.Errorf("parameter '%s': %w", name, err)
+		}
+	}
+
+	isRateMode := config.rate > 0 || hasBurst
+	if hasFirst && isRateMode {
+		return nil, errMixedSamplingModes
+	}
+	if !hasFirst && config.rate == 0 {
+		return nil, errMissingSamplingMode
+	}
+	if isRateMode && !hasBurst {
+		config.burst = math.Max(1, math.Ceil(config.rate))
+	}
+
+	return config, nil
+}
+
+func parsePositiveFloat(value string) (float64, error) {
+	f, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0, err
+	}
+	if f <= 0 || math.IsInf(f, 0) {
+		return 0, fmt.Errorf("non-positive or infinite value %s", value)
+	}
+
+	return f, nil
+}
+
+// samplingKey identifies a sampled message
+type samplingKey struct {
+	level   LogLevel
+	message string
+}
+
+// samplingCounter holds the sampling state of a message
+type samplingCounter struct {
+	count      uint64
+	suppressed uint64
+	tokens     float64
+	lastRefill time.Time
+}
+
+// samplingSummary holds the number of suppressed occurrences of a message in the last tick interval along with the
+// elapsed interval
+type samplingSummary struct {
+	samplingKey
+	suppressed uint64
+	interval   time.Duration
+}
+
+// messageSampler decides, for each log line of a logger, whether it is output or suppressed
+type messageSampler struct {
+	mut         sync.Mutex
+	config      samplingConfig
+	windowStart time.Time
+	counters    map[samplingKey]*samplingCounter
+}
+
+func newMessageSampler(config *samplingConfig) *messageSampler {
+	if config == nil {
+		return nil
+	}
+
+	return &messageSampler{
+		config:      *config,
+		windowStart: time.Now(),
+		counters:    make(map[samplingKey]*samplingCounter),
+	}
+}
+
+// sample returns true if the log line should be output. It also returns the summaries of the previous tick interval
+// if the provided time is past its end, whatever the level of the log line
+func (ms *messageSampler) sample(level LogLevel, message string, now time.Time) (bool, []samplingSummary) {
+	ms.mut.Lock()
+	defer ms.mut.Unlock()
+
+	var summaries []samplingSummary
+	if now.Sub(ms.windowStart) >= ms.config.tick {
+		summaries = ms.closeWindow(now)
+	}
+	if level > ms.config.maxLevel {
+		return true, summaries
+	}
+
+	key := samplingKey{
+		level:   level,
+		message: message,
+	}
+	counter, found := ms.counters[key]
+	if !found {
+		if len(ms.counters) >= maxSampledMessages {
+			return true, summaries
+		}
+
+		counter = &samplingCounter{
+			tokens:     ms.config.burst,
+			lastRefill: now,
+		}
+		ms.counters[key] = counter
+	}
+
+	isAllowed := ms.isAllowed(counter, now)
+	if !isAllowed {
+		counter.suppressed++
+	}
+
+	return isAllowed, summaries
+}
+
+// pendingSummaries returns the summaries of the previous tick interval if the provided time is past its end or,
+// if force is set, the summaries of the current tick interval
+func (ms *messageSampler) pendingSummaries(now time.Time, force bool) []samplingSummary {
+	ms.mut.Lock()
+	defer ms.mut.Unlock()
+
+	if !force && now.Sub(ms.windowStart) < ms.config.tick {
+		return nil
+	}
+
+	return ms.closeWindow(now)
+}
+
+func (ms *messageSampler) isAllowed(counter *samplingCounter, now time.Time) bool {
+	if ms.config.rate == 0 {
+		counter.count++
+		if counter.count <= ms.config.first {
+			return true
+		}
+
+		return ms.config.thereafter > 0 && (counter.count-ms.config.first)%ms.config.thereafter == 0
+	}
+
+	counter.refill(now, ms.config.rate, ms.config.burst)
+	if counter.tokens < 1 {
+		return false
+	}
+	counter.tokens--
+
+	return true
+}
+
+func (counter *samplingCounter) refill(now time.Time, rate float64, burst float64) {
+	elapsed := now.Sub(counter.lastRefill)
+	if elapsed <= 0 {
+		return
+	}
+
+	counter.tokens = math.Min(burst, counter.tokens+elapsed.Seconds()*rate)
+	counter.lastRefill = now
+}
+
+// closeWindow collects the summaries of the ending tick interval, ordered by level and message, and resets the
+// counters. The token buckets are kept unless they are full again, as a full bucket is equivalent to a missing one
+func (ms *messageSampler) closeWindow(now time.Time) []samplingSummary {
+	summaries := make([]samplingSummary, 0)
+	interval := now.Sub(ms.windowStart)
+	for key, counter := range ms.counters {
+		if counter.suppressed > 0 {
+			summaries = append(summaries, samplingSummary{
+				samplingKey: key,
+				suppressed:  counter.suppressed,
+				interval:    interval,
+			})
+		}
+
+		counter.suppressed = 0
+		counter.refill(now, ms.config.rate, ms.config.burst)
+		if ms.config.rate == 0 || counter.tokens >= ms.config.burst {
+			delete(ms.counters, key)
+		}
+	}
+	ms.windowStart = now
+	sort.Slice(summaries, func(i, j int) bool {
+		if summaries[i].level != summaries[j].level {
+			return summaries[i].level < summaries[j].level
+		}
+
+		return summaries[i].message < summaries[j].message
+	})
+
+	return summaries
+}
@@ -0,0 +1,327 @@
+package logger
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSamplingConfig(t *testing.T) {
+	t.Parallel()
+
+	config, err := parseSamplingConfig("off")
+	require.Nil(t, err)
+	assert.Nil(t, config)
+
+	config, err = parseSamplingConfig("first=10|thereafter=100")
+	require.Nil(t, err)
+	expected := &samplingConfig{
+		first:      10,
+		thereafter: 100,
+		tick:       time.Second,
+		maxLevel:   LogError,
+	}
+	assert.Equal(t, expected, config)
+
+	config, err = parseSamplingConfig("rate=2.5|tick=10s|level=DEBUG")
+	require.Nil(t, err)
+	expected = &samplingConfig{
+		rate:     2.5,
+		burst:    3,
+		tick:     10 * time.Second,
+		maxLevel: LogDebug,
+	}
+	assert.Equal(t, expected, config)
+
+	config, err = parseSamplingConfig("rate=0.1|burst=5")
+	require.Nil(t, err)
+	assert.Equal(t, float64(5), config.burst)
+	config, err = parseSamplingConfig("rate=0.1")
+	require.Nil(t, err)
+	assert.Equal(t, float64(1), config.burst)
+}
+
+func TestParseSamplingConfig_InvalidParametersShouldErr(t *testing.T) {
+	t.Parallel()
+
+	invalid := []string{
+		"",
+		"first",
+		"first=-1",
+		"first=10|rate=1",
+		"thereafter=10|burst=1",
+		"burst=10",
+		"rate=0",
+		"rate=Inf",
+		"first=1|tick=0s",
+		"first=1|tick=1",
+		"first=1|level=LOUD",
+		"first=1|level=FATAL",
+		"first=1|unknown=1",
+	}
+	for _, params := range invalid {
+		_, err := parseSamplingConfig(params)
+		assert.NotNil(t, err, params)
+	}
+}
+
+func TestSetSamplingPattern(t *testing.T) {
+	defer func() {
+		_ = SetSamplingPattern("")
+	}()
+
+	err := SetSamplingPattern("sampling/**")
+	assert.True(t, errors.Is(err, ErrInvalidSamplingPattern))
+	err = SetSamplingPattern("sampling/**:first=1,p2p:rate=x")
+	assert.True(t, errors.Is(err, ErrInvalidSamplingPattern))
+
+	log := GetOrCreate("sampling/process/sync")
+	assert.Nil(t, log.level.sampler)
+
+	pattern := "sampling/**:first=10,sampling/process/*:rate=5|level=DEBUG,sampling/process/block:off"
+	err = SetSamplingPattern(pattern)
+	require.Nil(t, err)
+	assert.Equal(t, pattern, GetSamplingPattern())
+	require.NotNil(t, log.level.sampler)
+	assert.Equal(t, float64(5), log.level.sampler.config.rate)
+	assert.Equal(t, LogDebug, log.level.sampler.config.maxLevel)
+	assert.Equal(t, uint64(10), GetOrCreate("sampling/p2p").level.sampler.config.first)
+	assert.Nil(t, GetOrCreate("sampling/process/block").level.sampler)
+	assert.Nil(t, GetOrCreate("sampling-other").level.sampler)
+
+	err = SetSamplingPattern("")
+	require.Nil(t, err)
+	assert.Nil(t, log.level.sampler)
+}
+
+func TestMessageSampler_FirstThereafter(t *testing.T) {
+	t.Parallel()
+
+	ms := newMessageSampler(&samplingConfig{
+		first:      2,
+		thereafter: 3,
+		tick:       time.Second,
+		maxLevel:   LogDebug,
+	})
+	start := time.Now()
+	ms.windowStart = start
+
+	sampled := make([]bool, 0)
+	for i := 0; i < 9; i++ {
+		isSampled, summaries := ms.sample(LogDebug, "message", start.Add(time.Duration(i)*time.Millisecond))
+		sampled = append(sampled, isSampled)
+		if i > 0 {
+			assert.Empty(t, summaries)
+		}
+	}
+	assert.Equal(t, []bool{true, true, false, false, true, false, false, true, false}, sampled)
+
+	isSampled, _ := ms.sample(LogDebug, "other message", start)
+	assert.True(t, isSampled)
+	isSampled, _ = ms.sample(LogInfo, "message", start)
+	assert.True(t, isSampled)
+
+	isSampled, summaries := ms.sample(LogTrace, "message", start.Add(time.Second))
+	assert.True(t, isSampled)
+	expected := []samplingSummary{
+		{
+			samplingKey: samplingKey{level: LogDebug, message: "message"},
+			suppressed:  5,
+			interval:    time.Second,
+		},
+	}
+	assert.Equal(t, expected, summaries)
+
+	isSampled, summaries = ms.sample(LogDebug, "message", start.Add(time.Second))
+	assert.True(t, isSampled)
+	assert.Empty(t, summaries)
+}
+
+func TestMessageSampler_TokenBucket(t *testing.T) {
+	t.Parallel()
+
+	ms := newMessageSampler(&samplingConfig{
+		rate:     10,
+		burst:    2,
+		tick:     time.Second,
+		maxLevel: LogError,
+	})
+	start := time.Now()
+	ms.windowStart = start
+
+	sampled := make([]bool, 0)
+	for i := 0; i < 4; i++ {
+		isSampled, _ := ms.sample(LogWarning, "message", start)
+		sampled = append(sampled, isSampled)
+	}
+	isSampled, _ := ms.sample(LogWarning, "message", start.Add(100*time.Millisecond))
+	sampled = append(sampled, isSampled)
+	isSampled, _ = ms.sample(LogWarning, "message", start.Add(150*time.Millisecond))
+	sampled = append(sampled, isSampled)
+	assert.Equal(t, []bool{true, true, false, false, true, false}, sampled)
+
+	isSampled, summaries := ms.sample(LogWarning, "message", start.Add(1100*time.Millisecond))
+	assert.True(t, isSampled)
+	require.Equal(t, 1, len(summaries))
+	assert.Equal(t, uint64(3), summaries[0].suppressed)
+	assert.Equal(t, 1, len(ms.counters))
+}
+
+func TestMessageSampler_ShouldNotTrackTooManyMessages(t *testing.T) {
+	t.Parallel()
+
+	ms := newMessageSampler(&samplingConfig{
+		tick:     time.Hour,
+		maxLevel: LogError,
+	})
+	now := time.Now()
+	for i := 0; i < maxSampledMessages; i++ {
+		isSampled, _ := ms.sample(LogInfo, fmt.Sprintf("message %d", i), now)
+		assert.False(t, isSampled)
+	}
+
+	isSampled, _ := ms.sample(LogInfo, "one message too many", now)
+	assert.True(t, isSampled)
+	assert.Equal(t, maxSampledMessages, len(ms.counters))
+}
+
+func TestLogger_SamplingShouldSuppressLinesAndOutputSummaries(t *testing.T) {
+	t.Parallel()
+
+	output := &capturingLogLineOutput{}
+	log := newLogger("test", LogTrace, output)
+	log.setSampler(newMessageSampler(&samplingConfig{
+		first:    1,
+		tick:     time.Hour,
+		maxLevel: LogDebug,
+	}))
+
+	for i := 0; i < 3; i++ {
+		log.Debug("debug message", "index", i)
+		log.DebugFields("debug fields message", Int("index", i))
+		log.Info("info message", "index", i)
+	}
+	require.Equal(t, 5, len(output.lines))
+	assert.Equal(t, "debug message", output.lines[0].Message)
+	assert.Equal(t, "debug fields message", output.lines[1].Message)
+
+	log.level.sampler.windowStart = time.Now().Add(-time.Hour)
+	log.Error("error message")
+
+	require.Equal(t, 8, len(output.lines))
+	for _, line := range output.lines[5:7] {
+		assert.Equal(t, samplingSummaryMessage, line.Message)
+		assert.Equal(t, LogDebug, line.LogLevel)
+		assert.Equal(t, "test", line.LoggerName)
+	}
+	assert.Equal(t, []interface{}{"message", "debug fields message", "suppressed", uint64(2), "interval"}, output.lines[5].Args[:5])
+	assert.Equal(t, []interface{}{"message", "debug message", "suppressed", uint64(2), "interval"}, output.lines[6].Args[:5])
+	assert.True(t, output.lines[5].Args[5].(time.Duration) >= time.Hour)
+	assert.Equal(t, output.lines[5].Args[5], output.lines[6].Args[5])
+	assert.Equal(t, "error message", output.lines[7].Message)
+}
+
+func TestMessageSampler_SummariesShouldHoldTheElapsedInterval(t *testing.T) {
+	t.Parallel()
+
+	ms := newMessageSampler(&samplingConfig{
+		first:    1,
+		tick:     time.Second,
+		maxLevel: LogError,
+	})
+	start := time.Now()
+	ms.windowStart = start
+	ms.sample(LogInfo, "message", start)
+	ms.sample(LogInfo, "message", start)
+
+	assert.Nil(t, ms.pendingSummaries(start.Add(time.Millisecond), false))
+	summaries := ms.pendingSummaries(start.Add(1500*time.Millisecond), false)
+	require.Equal(t, 1, len(summaries))
+	assert.Equal(t, 1500*time.Millisecond, summaries[0].interval)
+
+	ms.sample(LogInfo, "message", start.Add(1600*time.Millisecond))
+	ms.sample(LogInfo, "message", start.Add(1600*time.Millisecond))
+	summaries = ms.pendingSummaries(start.Add(1700*time.Millisecond), true)
+	require.Equal(t, 1, len(summaries))
+	assert.Equal(t, 200*time.Millisecond, summaries[0].interval)
+}
+
+func createSampledTestLogger(name string) (*logger, *capturingLogLineOutput) {
+	output := &capturingLogLineOutput{}
+	log := GetOrCreate(name)
+	log.logOutput = output
+
+	return log, output
+}
+
+func TestSetSamplingPattern_ShouldOutputTheSummariesPeriodically(t *testing.T) {
+	defer func() {
+		_ = SetSamplingPattern("")
+	}()
+
+	log, output := createSampledTestLogger("sampling-ticker")
+	err := SetSamplingPattern("=sampling-ticker:first=1|tick=10ms")
+	require.Nil(t, err)
+
+	log.Info("message")
+	log.Info("message")
+	log.Info("message")
+
+	require.Eventually(t, func() bool {
+		return output.numLines() == 2
+	}, time.Second, time.Millisecond)
+	line := output.getLine(1)
+	assert.Equal(t, samplingSummaryMessage, line.Message)
+	assert.Equal(t, []interface{}{"message", "message", "suppressed", uint64(2), "interval"}, line.Args[:5])
+	assert.True(t, line.Args[5].(time.Duration) >= 10*time.Millisecond)
+}
+
+func TestSetSamplingPattern_ShouldKeepOrFlushTheReplacedSamplers(t *testing.T) {
+	defer func() {
+		_ = SetSamplingPattern("")
+	}()
+
+	log, output := createSampledTestLogger("sampling-replaced")
+	err := SetSamplingPattern("=sampling-replaced:first=1|tick=1h")
+	require.Nil(t, err)
+	sampler := log.getSampler()
+
+	log.Info("message")
+	log.Info("message")
+	err = SetSamplingPattern("=sampling-replaced:first=1|tick=1h,other:first=2")
+	require.Nil(t, err)
+	assert.True(t, sampler == log.getSampler())
+	assert.Equal(t, 1, output.numLines())
+
+	err = SetSamplingPattern("=sampling-replaced:first=2|tick=1h")
+	require.Nil(t, err)
+	assert.False(t, sampler == log.getSampler())
+	require.Equal(t, 2, output.numLines())
+	assert.Equal(t, samplingSummaryMessage, output.getLine(1).Message)
+	assert.Equal(t, uint64(1), output.getLine(1).Args[3])
+}
+
+func TestShutdown_ShouldOutputThePendingSamplingSummaries(t *testing.T) {
+	defer replaceDefaultLogOut()()
+	defer func() {
+		_ = SetSamplingPattern("")
+	}()
+
+	log, output := createSampledTestLogger("sampling-shutdown")
+	err := SetSamplingPattern("=sampling-shutdown:first=1|tick=1h")
+	require.Nil(t, err)
+
+	log.Info("message")
+	log.Info("message")
+	require.Equal(t, 1, output.numLines())
+
+	err = Shutdown(context.Background())
+	require.Nil(t, err)
+	require.Equal(t, 2, output.numLines())
+	assert.Equal(t, samplingSummaryMessage, output.getLine(1).Message)
+}
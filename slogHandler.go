@@ -0,0 +1,146 @@
+//go:build go1.21
+
+package logger
+
+import (
+	"context"
+	"log/slog"
+)
+
+const slogGroupSeparator = "."
+
+var _ slog.Handler = (*slogHandler)(nil)
+
+// slogHandler is a slog.Handler that outputs the slog records through a registered logger, so the log level
+// patterns, the observers and the profile options apply on them as on any other log line
+type slogHandler struct {
+	log         *logger
+	args        []interface{}
+	groupPrefix string
+}
+
+// NewSlogHandler creates a slog.Handler that outputs the records through the logger registered under the provided
+// name. The slog levels are mapped on the closest log levels, while the groups and the attributes are converted
+// into log line arguments, the keys of the grouped attributes being prefixed by the group names ("group.key")
+func NewSlogHandler(loggerName string) *slogHandler {
+	return &slogHandler{
+		log:  GetOrCreate(loggerName),
+		args: make([]interface{}, 0),
+	}
+}
+
+// Enabled returns true if the logger's level allows the provided slog level
+func (sh *slogHandler) Enabled(_ context.Context, level slog.Level) bool {
+	return !sh.log.shouldSkipOutput(FromSlogLevel(level))
+}
+
+// Handle converts the slog record into a log line and outputs it. The correlation elements attached to the
+// provided context take precedence over the global ones. In the caller capture mode, the caller is taken from the
+// record program counter
+func (sh *slogHandler) Handle(ctx context.Context, record slog.Record) error {
+	args := make([]interface{}, 0, len(sh.args)+2*record.NumAttrs())
+	args = append(args, sh.args...)
+	record.Attrs(func(attr slog.Attr) bool {
+		args = appendSlogAttr(args, sh.groupPrefix, attr)
+		return true
+	})
+
+	line := newLogLine(sh.log.name, GetCorrelationFromContext(ctx), record.Message, FromSlogLevel(record.Level), args...)
+	if !record.Time.IsZero() {
+		line.Timestamp = record.Time
+	}
+	if record.PC != 0 && IsEnabledCaller() {
+		line.Caller = callerFromPC(record.PC)
+	}
+
+	sh.log.LogLine(line)
+
+	return nil
+}
+
+// WithAttrs returns a new handler that adds the provided attributes to each record
+func (sh *slogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	args := make([]interface{}, 0, len(sh.args)+2*len(attrs))
+	args = append(args, sh.args...)
+	for _, attr := range attrs {
+		args = appendSlogAttr(args, sh.groupPrefix, attr)
+	}
+
+	return &slogHandler{
+		log:         sh.log,
+		args:        args,
+		groupPrefix: sh.groupPrefix,
+	}
+}
+
+// WithGroup returns a new handler that prefixes the keys of all the following attributes with the group name
+func (sh *slogHandler) WithGroup(name string) slog.Handler {
+	if len(name) == 0 {
+		return sh
+	}
+
+	return &slogHandler{
+		log:         sh.log,
+		args:        sh.args,
+		groupPrefix: sh.groupPrefix + name + slogGroupSeparator,
+	}
+}
+
+func appendSlogAttr(args []interface{}, groupPrefix string, attr slog.Attr) []interface{} {
+	attr.Value = attr.Value.Resolve()
+	if attr.Equal(slog.Attr{}) {
+		return args
+	}
+
+	if attr.Value.Kind() != slog.KindGroup {
+		return append(args, groupPrefix+attr.Key, attr.Value.Any())
+	}
+
+	if len(attr.Key) > 0 {
+		groupPrefix += attr.Key + slogGroupSeparator
+	}
+	for _, groupAttr := range attr.Value.Group() {
+		args = appendSlogAttr(args, groupPrefix, groupAttr)
+	}
+
+	return args
+}
+
+// FromSlogLevel converts the provided slog level into the closest log level. The levels above slog.LevelError are
+// also converted into the error level, as the panic and fatal levels are reserved for the Panic and Fatal calls
+func FromSlogLevel(level slog.Level) LogLevel {
+	switch {
+	case level < slog.LevelDebug:
+		return LogTrace
+	case level < slog.LevelInfo:
+		return LogDebug
+	case level < slog.LevelWarn:
+		return LogInfo
+	case level < slog.LevelError:
+		return LogWarning
+	default:
+		return LogError
+	}
+}
+
+// ToSlogLevel converts the provided log level into the corresponding slog level. The trace level
+// is mapped 4 levels under slog.LevelDebug while the panic and fatal levels are mapped 4 and 8 levels above
+// slog.LevelError, following the slog levels spacing
+func ToSlogLevel(level LogLevel) slog.Level {
+	switch level {
+	case LogTrace:
+		return slog.LevelDebug - 4
+	case LogDebug:
+		return slog.LevelDebug
+	case LogInfo:
+		return slog.LevelInfo
+	case LogWarning:
+		return slog.LevelWarn
+	case LogPanic:
+		return slog.LevelError + 4
+	case LogFatal:
+		return slog.LevelError + 8
+	default:
+		return slog.LevelError
+	}
+}
@@ -0,0 +1,80 @@
+//go:build go1.21
+
+package logger
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+)
+
+// slogOutput outputs the log lines of a logger through a slog.Handler
+type slogOutput struct {
+	handler slog.Handler
+}
+
+// GetOrCreateWithSlogHandler generates a new log with the provided name that outputs its lines through the provided
+// slog.Handler instead of the default log output subject. As any other generated log, it is registered so the log
+// level patterns and the profile changes apply on it. As the output of an existing log cannot be changed, an error
+// is returned if a log with the provided name already exists, such as one created by GetOrCreate
+func GetOrCreateWithSlogHandler(name string, handler slog.Handler) (*logger, error) {
+	if handler == nil {
+		return nil, ErrNilSlogHandler
+	}
+
+	logMut.Lock()
+	defer logMut.Unlock()
+
+	_, ok := loggers[name]
+	if ok {
+		return nil, fmt.Errorf("%w: %s", ErrLoggerAlreadyExists, name)
+	}
+
+	logLevel := logLevelRules.logLevelFor(name, defaultLogLevel)
+	log := newLogger(name, logLevel, &slogOutput{handler: handler})
+	log.setStackTraceLevel(stackTraceLevelFor(name))
+	log.setSampler(samplerFor(name))
+	loggers[name] = log
+
+	return log, nil
+}
+
+// Output converts the log line into a slog record and hands it to the slog.Handler. The log line arguments and
+// fields are added as attributes, the logger name, the correlation elements, the caller location and the stack
+// trace being also added if enabled
+func (so *slogOutput) Output(line *LogLine) {
+	if line == nil {
+		return
+	}
+
+	ctx := context.Background()
+	level := ToSlogLevel(line.LogLevel)
+	if !so.handler.Enabled(ctx, level) {
+		return
+	}
+
+	record := slog.NewRecord(line.Timestamp, level, line.Message, 0)
+	if IsEnabledLoggerName() {
+		record.AddAttrs(slog.String("logger", line.LoggerName))
+	}
+	if IsEnabledCorrelation() {
+		record.AddAttrs(slog.Group("correlation",
+			slog.String("shard", line.Correlation.Shard),
+			slog.Uint64("epoch", uint64(line.Correlation.Epoch)),
+			slog.Int64("round", line.Correlation.Round),
+			slog.String("subround", line.Correlation.SubRound),
+		))
+	}
+	if hasCaller(line.Caller) {
+		record.AddAttrs(slog.String("caller", string(appendCallerLocation(nil, line.Caller))))
+	}
+	if len(line.StackTrace) > 0 {
+		record.AddAttrs(slog.String("stacktrace", line.StackTrace))
+	}
+	record.Add(resolveLogValues(line.Args)...)
+	for _, field := range line.Fields {
+		record.AddAttrs(slog.Any(field.Key, field.Value()))
+	}
+
+	_ = so.handler.Handle(ctx, record)
+}
@@ -0,0 +1,142 @@
+//go:build go1.21
+
+package logger_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	logger "github.com/Dharitri-org/me-core-logger-go"
+	"github.com/Dharitri-org/me-core-logger-go/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var numSlogLoggers uint32
+
+// newSlogLoggerName returns a logger name not used by the previous test runs, as the created loggers are kept
+func newSlogLoggerName(t *testing.T) string {
+	return fmt.Sprintf("%s-%d", t.Name(), atomic.AddUint32(&numSlogLoggers, 1))
+}
+
+func TestSlogLevelsConversion(t *testing.T) {
+	t.Parallel()
+
+	for _, level := range []logger.LogLevel{logger.LogTrace, logger.LogDebug, logger.LogInfo, logger.LogWarning, logger.LogError} {
+		assert.Equal(t, level, logger.FromSlogLevel(logger.ToSlogLevel(level)))
+	}
+	assert.Equal(t, logger.LogDebug, logger.FromSlogLevel(slog.LevelDebug+1))
+	assert.Equal(t, logger.LogError, logger.FromSlogLevel(slog.LevelError+4))
+	assert.Equal(t, slog.LevelError+4, logger.ToSlogLevel(logger.LogPanic))
+	assert.Equal(t, slog.LevelError+8, logger.ToSlogLevel(logger.LogFatal))
+}
+
+func TestSlogHandler_ShouldOutputThroughTheRegisteredLogger(t *testing.T) {
+	gatherer := &mock.DummyLogsGatherer{}
+	_ = logger.AddLogObserver(gatherer, gatherer)
+	defer func() {
+		_ = logger.RemoveLogObserver(gatherer)
+	}()
+
+	err := logger.SetLogLevel("*:INFO,slog-handler:DEBUG")
+	require.Nil(t, err)
+	defer func() {
+		_ = logger.SetLogLevel("*:INFO")
+	}()
+
+	slogLogger := slog.New(logger.NewSlogHandler("slog-handler"))
+	slogLogger.With("peer", "pid").WithGroup("block").Debug(
+		"slog message",
+		"nonce", 7,
+		slog.Group("header", "hash", []byte{1, 2}),
+	)
+	slogLogger.Log(context.Background(), slog.LevelDebug-4, "slog trace message")
+
+	require.True(t, gatherer.ContainsLogLine("slog-handler", logger.LogDebug, "slog message"))
+	require.False(t, gatherer.ContainsText("slog trace message"))
+	require.Equal(t, "slog message\npeer\npid\nblock.nonce\n7\nblock.header.hash\n0102\n", gatherer.GetText())
+}
+
+func TestSlogHandler_EnabledShouldFollowTheLoggerLevel(t *testing.T) {
+	handler := logger.NewSlogHandler("slog-enabled")
+	logger.GetOrCreate("slog-enabled").SetLevel(logger.LogWarning)
+
+	assert.False(t, handler.Enabled(context.Background(), slog.LevelInfo))
+	assert.True(t, handler.Enabled(context.Background(), slog.LevelWarn))
+}
+
+func TestGetOrCreateWithSlogHandler(t *testing.T) {
+	_, err := logger.GetOrCreateWithSlogHandler("slog-backed-nil", nil)
+	require.Equal(t, logger.ErrNilSlogHandler, err)
+
+	buff := &bytes.Buffer{}
+	textHandler := slog.NewTextHandler(buff, &slog.HandlerOptions{Level: slog.LevelDebug - 4})
+	name := newSlogLoggerName(t)
+	log, err := logger.GetOrCreateWithSlogHandler(name, textHandler)
+	require.Nil(t, err)
+	require.True(t, log == logger.GetOrCreate(name))
+
+	_, err = logger.GetOrCreateWithSlogHandler(name, slog.NewTextHandler(&bytes.Buffer{}, nil))
+	require.ErrorIs(t, err, logger.ErrLoggerAlreadyExists)
+
+	log.Debug("not displayed")
+	require.Empty(t, buff.String())
+
+	err = logger.SetLogLevel("*:INFO,=" + name + ":TRACE")
+	require.Nil(t, err)
+	defer func() {
+		_ = logger.SetLogLevel("*:INFO")
+	}()
+
+	log.Trace("displayed", "key", "value")
+	assert.Contains(t, buff.String(), "level=DEBUG-4 msg=displayed")
+	assert.Contains(t, buff.String(), "key=value\n")
+	assert.Equal(t, logger.LogTrace, logger.GetLoggerLogLevel(name))
+}
+
+func TestSlogHandler_ShouldUseTheRecordCaller(t *testing.T) {
+	logger.ToggleCaller(true)
+	defer logger.ToggleCaller(false)
+
+	name := newSlogLoggerName(t)
+	lines := make([]logger.LogLineHandler, 0)
+	w := &mock.WriterStub{
+		WriteCalled: func(p []byte) (n int, err error) {
+			return len(p), nil
+		},
+	}
+	_ = logger.AddLogObserverWithOptions(w, &mock.FormatterStub{
+		OutputCalled: func(line logger.LogLineHandler) []byte {
+			lines = append(lines, line)
+			return nil
+		},
+	}, logger.ObserverOptions{LoggerNamePattern: "=" + name})
+	defer func() {
+		_ = logger.RemoveLogObserver(w)
+	}()
+
+	slog.New(logger.NewSlogHandler(name)).Info("with caller")
+
+	require.Equal(t, 1, len(lines))
+	caller := lines[0].(logger.CallerHandler).GetCaller()
+	assert.True(t, strings.HasSuffix(caller.File, "slog_test.go"))
+	assert.Contains(t, caller.Function, "TestSlogHandler_ShouldUseTheRecordCaller")
+}
+
+func TestGetOrCreateWithSlogHandler_ShouldAddTheCaller(t *testing.T) {
+	logger.ToggleCaller(true)
+	defer logger.ToggleCaller(false)
+
+	buff := &bytes.Buffer{}
+	name := newSlogLoggerName(t)
+	log, err := logger.GetOrCreateWithSlogHandler(name, slog.NewTextHandler(buff, nil))
+	require.Nil(t, err)
+
+	log.Info("with caller")
+	assert.Contains(t, buff.String(), "/slog_test.go:")
+}
@@ -0,0 +1,252 @@
+package logger
+
+import (
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// maxStackTraceDepth is the maximum number of frames written in a stack trace
+const maxStackTraceDepth = 64
+
+// maxErrorCauses is the maximum number of wrapped causes unrolled for an error argument
+const maxErrorCauses = 32
+
+const errorCauseSuffix = ".cause"
+const errorFieldKey = "error"
+
+var globalStackTrace stackTraceSettings
+
+// stackTraceSettings holds the stack trace attachment settings
+type stackTraceSettings struct {
+	mut             sync.RWMutex
+	pattern         string
+	rules           *logLevelRuleSet
+	withOnErrorArgs bool
+}
+
+func init() {
+	globalStackTrace.rules, _ = newLogLevelRuleSet(nil, nil)
+}
+
+// SetStackTracePattern sets, for the loggers matched by the provided pattern, the minimum level of the log lines that
+// get a stack trace attached. The expected format is the one used by SetLogLevel:
+// "MATCHING_STRING1:LOG_LEVEL1,MATCHING_STRING2:LOG_LEVEL2". For example, "*:ERROR,process/**:WARN" attaches
+// stack traces to the ERROR lines of all loggers and to the WARN and ERROR lines of the process subtree.
+// The NONE level or an empty pattern disable the stack traces. The errors wrapped by the error arguments of a line
+// having a stack trace are unrolled into separate arguments.
+func SetStackTracePattern(pattern string) error {
+	ruleSet, err := newStackTraceRuleSet(pattern)
+	if err != nil {
+		return err
+	}
+
+	globalStackTrace.mut.Lock()
+	globalStackTrace.pattern = pattern
+	globalStackTrace.rules = ruleSet
+	globalStackTrace.mut.Unlock()
+
+	logMut.RLock()
+	for name, log := range loggers {
+		log.setStackTraceLevel(ruleSet.logLevelFor(name, LogNone))
+	}
+	logMut.RUnlock()
+
+	return nil
+}
+
+func newStackTraceRuleSet(pattern string) (*logLevelRuleSet, error) {
+	if len(pattern) == 0 {
+		return newLogLevelRuleSet(nil, nil)
+	}
+
+	return parseLogLevelRuleSet(pattern)
+}
+
+// GetStackTracePattern returns the last set stack trace pattern
+func GetStackTracePattern() string {
+	globalStackTrace.mut.RLock()
+	defer globalStackTrace.mut.RUnlock()
+
+	return globalStackTrace.pattern
+}
+
+// stackTraceLevelFor returns the minimum level of the log lines that get a stack trace for the provided logger name
+func stackTraceLevelFor(loggerName string) LogLevel {
+	globalStackTrace.mut.RLock()
+	defer globalStackTrace.mut.RUnlock()
+
+	return globalStackTrace.rules.logLevelFor(loggerName, LogNone)
+}
+
+// ToggleStackTraceOnErrorArgs enables / disables the stack trace attachment for all the log lines having an error
+// argument, whatever their level
+func ToggleStackTraceOnErrorArgs(enable bool) {
+	globalStackTrace.mut.Lock()
+	globalStackTrace.withOnErrorArgs = enable
+	globalStackTrace.mut.Unlock()
+}
+
+// IsEnabledStackTraceOnErrorArgs returns whether the log lines having an error argument get a stack trace
+func IsEnabledStackTraceOnErrorArgs() bool {
+	globalStackTrace.mut.RLock()
+	enabled := globalStackTrace.withOnErrorArgs
+	globalStackTrace.mut.RUnlock()
+
+	return enabled
+}
+
+// captureStackTrace returns the stack trace starting with the frame found at the provided skip depth. Each frame is
+// written on two lines, as the go runtime does: the function on the first one and the file:line on the second one,
+// both indented with tabs
+func captureStackTrace(skip int) string {
+	var pcs [maxStackTraceDepth]uintptr
+	numFrames := runtime.Callers(skip+1, pcs[:])
+	if numFrames == 0 {
+		return ""
+	}
+
+	builder := strings.Builder{}
+	frames := runtime.CallersFrames(pcs[:numFrames])
+	for {
+		frame, more := frames.Next()
+		if frame.Function != "runtime.goexit" {
+			builder.WriteString("\t")
+			builder.WriteString(frame.Function)
+			builder.WriteString("\n\t\t")
+			builder.WriteString(frame.File)
+			builder.WriteString(":")
+			builder.WriteString(strconv.Itoa(frame.Line))
+			builder.WriteString("\n")
+		}
+		if !more {
+			return builder.String()
+		}
+	}
+}
+
+// getStackTrace returns the stack trace of the provided log line or an empty string if it is not a StackTraceHandler
+func getStackTrace(line LogLineHandler) string {
+	stackTraceLine, ok := line.(StackTraceHandler)
+	if !ok {
+		return ""
+	}
+
+	return stackTraceLine.GetStackTrace()
+}
+
+func hasErrorArg(args []interface{}) bool {
+	for _, arg := range args {
+		if _, isError := arg.(error); isError {
+			return true
+		}
+	}
+
+	return false
+}
+
+func hasErrorField(fields []Field) bool {
+	for i := range fields {
+		if fields[i].fieldType == errorField {
+			return true
+		}
+		if _, isError := fields[i].value.(error); isError {
+			return true
+		}
+	}
+
+	return false
+}
+
+// appendErrorCausesArgs returns the arguments followed by the unrolled causes of the error arguments, as
+// "<name>.cause1", "cause 1 message", "<name>.cause2", "cause 2 message" ... pairs. An odd last argument is kept after
+// the causes, so the pairs are not shifted. A new slice is returned so the caller's slice is never changed
+func appendErrorCausesArgs(args []interface{}, err error, errName string) []interface{} {
+	numPairedArgs := len(args) - len(args)%2
+	allArgs := make([]interface{}, 0, len(args)+2)
+	allArgs = append(allArgs, args[:numPairedArgs]...)
+	allArgs = appendErrorCauses(allArgs, errName, err)
+	for index := 1; index < numPairedArgs; index += 2 {
+		argErr, isError := args[index].(error)
+		if isError {
+			allArgs = appendErrorCauses(allArgs, displayArgName(args[index-1]), argErr)
+		}
+	}
+	if numPairedArgs == len(args) {
+		return allArgs
+	}
+
+	danglingArg := args[numPairedArgs]
+	argErr, isError := danglingArg.(error)
+	if isError {
+		allArgs = appendErrorCauses(allArgs, errorFieldKey, argErr)
+	}
+
+	return append(allArgs, danglingArg)
+}
+
+// appendErrorCausesFields returns the fields followed by the unrolled causes of the error fields
+func appendErrorCausesFields(fields []Field, err error, errName string) []Field {
+	causes := appendErrorCauses(nil, errName, err)
+	for i := range fields {
+		fieldErr, isError := fields[i].value.(error)
+		if isError {
+			causes = appendErrorCauses(causes, fields[i].Key, fieldErr)
+		}
+	}
+	for index := 1; index < len(causes); index += 2 {
+		fields = append(fields, String(causes[index-1].(string), causes[index].(string)))
+	}
+
+	return fields
+}
+
+func displayArgName(name interface{}) string {
+	str, isString := name.(string)
+	if isString {
+		return str
+	}
+
+	return displayArgument(ToHex, name)
+}
+
+// appendErrorCauses appends, depth first, the wrapped causes of the provided error (without the error itself).
+// Both the errors wrapping a single error and the ones wrapping multiple errors (such as the ones created by
+// errors.Join) are unrolled
+func appendErrorCauses(args []interface{}, name string, err error) []interface{} {
+	if err == nil {
+		return args
+	}
+
+	causes := make([]error, 0)
+	causes = collectErrorCauses(causes, err)
+	for i, cause := range causes {
+		args = append(args, name+errorCauseSuffix+strconv.Itoa(i+1), cause.Error())
+	}
+
+	return args
+}
+
+func collectErrorCauses(causes []error, err error) []error {
+	switch wrapper := err.(type) {
+	case interface{ Unwrap() error }:
+		return appendErrorCause(causes, wrapper.Unwrap())
+	case interface{ Unwrap() []error }:
+		for _, cause := range wrapper.Unwrap() {
+			causes = appendErrorCause(causes, cause)
+		}
+	}
+
+	return causes
+}
+
+func appendErrorCause(causes []error, cause error) []error {
+	if cause == nil || len(causes) >= maxErrorCauses {
+		return causes
+	}
+
+	causes = append(causes, cause)
+
+	return collectErrorCauses(causes, cause)
+}
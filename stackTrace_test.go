@@ -0,0 +1,183 @@
+package logger
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type capturingLogLineOutput struct {
+	mut   sync.Mutex
+	lines []*LogLine
+}
+
+func (clo *capturingLogLineOutput) Output(line *LogLine) {
+	clone := *line
+	clone.Args = append([]interface{}(nil), line.Args...)
+	clone.Fields = append([]Field(nil), line.Fields...)
+
+	clo.mut.Lock()
+	clo.lines = append(clo.lines, &clone)
+	clo.mut.Unlock()
+}
+
+func (clo *capturingLogLineOutput) numLines() int {
+	clo.mut.Lock()
+	defer clo.mut.Unlock()
+
+	return len(clo.lines)
+}
+
+func (clo *capturingLogLineOutput) getLine(index int) *LogLine {
+	clo.mut.Lock()
+	defer clo.mut.Unlock()
+
+	return clo.lines[index]
+}
+
+func TestAppendErrorCauses(t *testing.T) {
+	t.Parallel()
+
+	root := errors.New("root")
+	wrapped := fmt.Errorf("wrapped: %w", root)
+	joined := errors.Join(wrapped, errors.New("other"))
+	top := fmt.Errorf("top: %w", joined)
+
+	assert.Nil(t, appendErrorCauses(nil, "error", nil))
+	assert.Nil(t, appendErrorCauses(nil, "error", root))
+	expected := []interface{}{
+		"err.cause1", "wrapped: root\nother",
+		"err.cause2", "wrapped: root",
+		"err.cause3", "root",
+		"err.cause4", "other",
+	}
+	assert.Equal(t, expected, appendErrorCauses(nil, "err", top))
+}
+
+func TestAppendErrorCausesArgs_ShouldNotChangeTheProvidedArgs(t *testing.T) {
+	t.Parallel()
+
+	args := make([]interface{}, 0, 10)
+	args = append(args, "err", fmt.Errorf("a: %w", errors.New("b")), "dangling", fmt.Errorf("c: %w", errors.New("d")))
+	allArgs := appendErrorCausesArgs(args[:3], errors.Join(errors.New("e")), errorFieldKey)
+
+	expected := []interface{}{
+		args[0], args[1],
+		"error.cause1", "e",
+		"err.cause1", "b",
+		args[2],
+	}
+	assert.Equal(t, expected, allArgs)
+	assert.Equal(t, "c: d", args[3].(error).Error())
+}
+
+func TestSetStackTracePattern(t *testing.T) {
+	defer func() {
+		_ = SetStackTracePattern("")
+	}()
+
+	err := SetStackTracePattern("process")
+	assert.True(t, errors.Is(err, ErrInvalidLogLevelPattern))
+
+	name := "stack-trace/process/sync"
+	log := GetOrCreate(name)
+	assert.Equal(t, LogNone, log.level.stackTraceLevel)
+
+	err = SetStackTracePattern("*:ERROR,stack-trace/**:WARN")
+	require.Nil(t, err)
+	assert.Equal(t, "*:ERROR,stack-trace/**:WARN", GetStackTracePattern())
+	assert.Equal(t, LogWarning, log.level.stackTraceLevel)
+	assert.Equal(t, LogError, GetOrCreate("stack-trace-other").level.stackTraceLevel)
+
+	err = SetStackTracePattern("")
+	require.Nil(t, err)
+	assert.Equal(t, LogNone, log.level.stackTraceLevel)
+}
+
+func TestLogger_StackTraceShouldBeAttachedFromTheLevel(t *testing.T) {
+	t.Parallel()
+
+	output := &capturingLogLineOutput{}
+	log := newLogger("test", LogTrace, output)
+	log.setStackTraceLevel(LogWarning)
+
+	log.Info("info message", "a", 1)
+	log.Warn("warn message", "a", 1)
+	log.ErrorFields("error message", Int("a", 1))
+
+	require.Equal(t, 3, len(output.lines))
+	assert.Empty(t, output.lines[0].StackTrace)
+	for _, line := range output.lines[1:] {
+		assert.True(t, strings.HasPrefix(line.StackTrace, "\tgithub.com/Dharitri-org/me-core-logger-go.TestLogger_StackTraceShouldBeAttachedFromTheLevel\n"))
+		assert.Contains(t, line.StackTrace, "stackTrace_test.go:")
+	}
+}
+
+func TestLogger_StackTraceShouldUnrollTheErrorCauses(t *testing.T) {
+	t.Parallel()
+
+	output := &capturingLogLineOutput{}
+	log := newLogger("test", LogTrace, output)
+	log.setStackTraceLevel(LogError)
+
+	err := fmt.Errorf("top: %w", errors.New("root"))
+	log.LogIfError(err, "a", 1)
+	log.Error("message", "cause", err)
+	log.ErrorFields("message", Err(err))
+	log.Warn("message", "cause", err)
+
+	require.Equal(t, 4, len(output.lines))
+	assert.Equal(t, "top: root", output.lines[0].Message)
+	assert.Equal(t, []interface{}{"a", 1, "error.cause1", "root"}, output.lines[0].Args)
+	assert.Equal(t, []interface{}{"cause", err, "cause.cause1", "root"}, output.lines[1].Args)
+	assert.Equal(t, []Field{Err(err), String("error.cause1", "root")}, output.lines[2].Fields)
+	assert.Equal(t, []interface{}{"cause", err}, output.lines[3].Args)
+	assert.Empty(t, output.lines[3].StackTrace)
+}
+
+func TestLogger_StackTraceShouldKeepTheOddArgumentLast(t *testing.T) {
+	t.Parallel()
+
+	output := &capturingLogLineOutput{}
+	log := newLogger("test", LogTrace, output)
+	log.setStackTraceLevel(LogError)
+
+	wrapped := fmt.Errorf("wrap: %w", errors.New("root"))
+	log.Error("odd error arg", wrapped)
+	log.LogIfError(wrapped, "k")
+	log.Error("message", "a", 1, "dangling")
+
+	require.Equal(t, 3, len(output.lines))
+	assert.Equal(t, []interface{}{"error.cause1", "root", wrapped}, output.lines[0].Args)
+	assert.Equal(t, []interface{}{"error.cause1", "root", "k"}, output.lines[1].Args)
+	assert.Equal(t, []interface{}{"a", 1, "dangling"}, output.lines[2].Args)
+
+	los := NewLogOutputSubject()
+	formatted := (&PlainFormatter{}).Output(los.convertLogLine(output.lines[1]))
+	assert.Contains(t, string(formatted), "error.cause1 = root")
+	assert.NotContains(t, string(formatted), "k = ")
+}
+
+func TestLogger_StackTraceOnErrorArgs(t *testing.T) {
+	ToggleStackTraceOnErrorArgs(true)
+	defer ToggleStackTraceOnErrorArgs(false)
+
+	output := &capturingLogLineOutput{}
+	log := newLogger("test", LogTrace, output)
+
+	log.Debug("message", "a", 1)
+	log.Debug("message", "err", errors.New("expected error"))
+	log.DebugFields("message", Any("err", errors.New("expected error")))
+	log.LogIfError(errors.New("expected error"))
+
+	require.Equal(t, 4, len(output.lines))
+	assert.Empty(t, output.lines[0].StackTrace)
+	assert.NotEmpty(t, output.lines[1].StackTrace)
+	assert.NotEmpty(t, output.lines[2].StackTrace)
+	assert.NotEmpty(t, output.lines[3].StackTrace)
+}